@@ -66,7 +66,7 @@ func main() {
 	flag.BoolVar(&isShowVersion, "version", false, "version information")
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "\n%s - %s\n\n", app.Name, app.Description)
-		fmt.Fprintf(os.Stderr, "Usage: %s [arguments]\n\n", app.Name)
+		fmt.Fprintf(os.Stderr, "Usage: %s [arguments] [template [template arguments]]\n\n", app.Name)
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nDocumentation: %s\n\n", app.Documentation)
 	}
@@ -82,6 +82,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	var tmplCmd *templateCommand
+	if flag.NArg() > 0 {
+		if flag.Arg(0) != "template" {
+			log.Fatalf("unsupported command: %s", flag.Arg(0))
+		}
+		cmd, err := newTemplateCommand(flag.Args()[1:])
+		if err != nil {
+			log.Fatalf("template: %s", err)
+		}
+		if !cmd.isRemote() {
+			fmt.Fprintf(os.Stdout, "%s", cmd.conf.Text)
+			os.Exit(0)
+		}
+		tmplCmd = cmd
+	}
+
 	cli := client.NewClient()
 	if err := cli.SetHost(host); err != nil {
 		log.Fatalf("argument '-host': %s", err)
@@ -105,6 +121,13 @@ func main() {
 	}
 	log.Debugf("host: %s, port: %d, secure: %t,  user: %s, cli command: %s", host, port, secure, authUser, cliCommand)
 
+	if tmplCmd != nil {
+		if err := tmplCmd.run(cli); err != nil {
+			log.Fatalf("template: %s", err)
+		}
+		return
+	}
+
 	switch cliCommand {
 	case "":
 		log.Fatalf("argument '-cli' is empty")
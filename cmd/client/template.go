@@ -0,0 +1,101 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/greenpau/go-cisco-nx-api/pkg/client"
+)
+
+// templateCommand renders a configuration template with the variables of
+// a device, and shows, diffs or pushes the rendered configuration.
+type templateCommand struct {
+	name  string
+	diff  bool
+	prune bool
+	push  bool
+	conf  *client.Configuration
+}
+
+func newTemplateCommand(args []string) (*templateCommand, error) {
+	var files, varsFile string
+	c := &templateCommand{}
+	fs := flag.NewFlagSet("template", flag.ContinueOnError)
+	fs.StringVar(&files, "file", "", "template files, separated by commas")
+	fs.StringVar(&varsFile, "vars", "", "device variables file (json)")
+	fs.BoolVar(&c.diff, "diff", false, "show the difference from running configuration")
+	fs.BoolVar(&c.prune, "prune", false, "remove the lines absent from the template sections, with -diff")
+	fs.BoolVar(&c.push, "push", false, "push the configuration, only the difference with -diff")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "\nUsage: %s [arguments] template [template arguments]\n\n", app.Name)
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if files == "" {
+		return nil, fmt.Errorf("argument '-file' is empty")
+	}
+	if varsFile == "" {
+		return nil, fmt.Errorf("argument '-vars' is empty")
+	}
+	tmpl, err := client.NewConfigTemplateFromFiles(strings.Split(files, ",")...)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := client.NewDeviceVarsFromFile(varsFile)
+	if err != nil {
+		return nil, err
+	}
+	c.conf, err = tmpl.Render(vars)
+	if err != nil {
+		return nil, err
+	}
+	c.name = tmpl.Name
+	return c, nil
+}
+
+// isRemote returns true when the command needs the connection to the
+// device.
+func (c *templateCommand) isRemote() bool {
+	return c.diff || c.push
+}
+
+func (c *templateCommand) run(cli *client.Client) error {
+	if !c.push {
+		diff, err := cli.DiffConfig(c.conf, c.prune)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stdout, "%s", diff.Unified("running-config", c.name))
+		return nil
+	}
+	result, err := cli.PushConfig(c.conf, client.ConfigPushOptions{Diff: c.diff, Prune: c.prune})
+	if result != nil && result.Diff != nil {
+		fmt.Fprintf(os.Stdout, "%s", result.Diff.Unified("running-config", c.name))
+	}
+	if err != nil {
+		return err
+	}
+	for _, cmd := range result.Commands {
+		fmt.Fprintf(os.Stdout, "%s\n", cmd)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+	"time"
+)
+
+type tacacsServerResponse struct {
+	ID      uint64                     `json:"id" xml:"id"`
+	Version string                     `json:"jsonrpc" xml:"jsonrpc"`
+	Result  tacacsServerResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError      `json:"error,omitempty" xml:"error"`
+}
+
+type tacacsServerResponseResult struct {
+	Body tacacsServerResponseResultBody `json:"body" xml:"body"`
+}
+
+type tacacsServerResponseResultBody struct {
+	Timeout         int64  `json:"timeout" xml:"timeout"`
+	Deadtime        int64  `json:"deadtime" xml:"deadtime"`
+	SourceInterface string `json:"src_intf" xml:"src_intf"`
+	ServerTable     []struct {
+		ServerRow []struct {
+			Server  string `json:"tacacs_server" xml:"tacacs_server"`
+			Port    int    `json:"available_on_port" xml:"available_on_port"`
+			Timeout int64  `json:"timeout" xml:"timeout"`
+		} `json:"ROW_tacacsServer" xml:"ROW_tacacsServer"`
+	} `json:"TABLE_tacacsServer" xml:"TABLE_tacacsServer"`
+}
+
+// TACACSServer is a TACACS+ server of TACACSServers.
+type TACACSServer struct {
+	Address string        `json:"address" xml:"address"`
+	Port    int           `json:"port" xml:"port"`
+	Timeout time.Duration `json:"timeout" xml:"timeout"`
+}
+
+// TACACSServers contains TACACS+ client configuration. The information in
+// the structure is from the output of "show tacacs-server" command.
+type TACACSServers struct {
+	Timeout         time.Duration  `json:"timeout" xml:"timeout"`
+	Deadtime        time.Duration  `json:"deadtime" xml:"deadtime"`
+	SourceInterface string         `json:"source_interface" xml:"source_interface"`
+	Servers         []TACACSServer `json:"servers" xml:"servers"`
+}
+
+// AAAAuthentication contains the authentication methods of a service,
+// e.g. "default" or "console". The Other holds the methods and the states
+// not known to the parser, e.g. "disabled" of "mschap". The information in
+// the structure is from the output of "show aaa authentication" command.
+type AAAAuthentication struct {
+	Service    string   `json:"service" xml:"service"`
+	Configured bool     `json:"configured" xml:"configured"`
+	Groups     []string `json:"groups" xml:"groups"`
+	Local      bool     `json:"local" xml:"local"`
+	None       bool     `json:"none" xml:"none"`
+	Other      []string `json:"other" xml:"other"`
+}
+
+// NewTACACSServersFromString returns TACACSServers instance from an input
+// string.
+func NewTACACSServersFromString(s string) (*TACACSServers, error) {
+	return NewTACACSServersFromBytes([]byte(s))
+}
+
+// NewTACACSServersFromBytes returns TACACSServers instance from an input
+// byte array.
+func NewTACACSServersFromBytes(s []byte) (*TACACSServers, error) {
+	resp := &tacacsServerResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	body := resp.Result.Body
+	servers := &TACACSServers{
+		Timeout:         time.Duration(body.Timeout) * time.Second,
+		Deadtime:        time.Duration(body.Deadtime) * time.Minute,
+		SourceInterface: body.SourceInterface,
+		Servers:         []TACACSServer{},
+	}
+	for _, t := range body.ServerTable {
+		for _, r := range t.ServerRow {
+			server := TACACSServer{
+				Address: r.Server,
+				Port:    r.Port,
+				Timeout: time.Duration(r.Timeout) * time.Second,
+			}
+			// a server without its own timeout uses the global one.
+			if server.Timeout == 0 {
+				server.Timeout = servers.Timeout
+			}
+			servers.Servers = append(servers.Servers, server)
+		}
+	}
+	return servers, nil
+}
+
+// NewAAAAuthenticationFromString returns AAAAuthentication instances from
+// an input string.
+func NewAAAAuthenticationFromString(s string) ([]*AAAAuthentication, error) {
+	return NewAAAAuthenticationFromBytes([]byte(s))
+}
+
+// NewAAAAuthenticationFromBytes returns AAAAuthentication instances from
+// an input byte array.
+func NewAAAAuthenticationFromBytes(s []byte) ([]*AAAAuthentication, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseAAAAuthentication(resp.Result.Outputs.Output.Body)
+}
+
+// parseAAAAuthentication parses the text output of "show aaa authentication",
+// e.g.
+//
+//	default: group TACACS-SERVERS local
+//	console: local
+//	dot1x: not configured
+//	mschap: disabled
+func parseAAAAuthentication(s string) ([]*AAAAuthentication, error) {
+	var entries []*AAAAuthentication
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i < 1 {
+			return nil, fmt.Errorf("malformed aaa authentication entry: %s", line)
+		}
+		entry := &AAAAuthentication{
+			Service: strings.TrimSpace(line[:i]),
+			Groups:  []string{},
+			Other:   []string{},
+		}
+		methods := strings.TrimSpace(line[i+1:])
+		if methods != "not configured" {
+			entry.Configured = methods != "disabled"
+			inGroup := false
+			for _, method := range strings.Fields(methods) {
+				switch method {
+				case "group":
+					inGroup = true
+				case "local":
+					entry.Local = true
+					inGroup = false
+				case "none":
+					entry.None = true
+					inGroup = false
+				default:
+					if !inGroup {
+						entry.Other = append(entry.Other, method)
+						continue
+					}
+					entry.Groups = append(entry.Groups, method)
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShowAAAAuthenticationOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *AAAAuthentication
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.aaa.authentication.1",
+			exp: &AAAAuthentication{
+				Service:    "default",
+				Configured: true,
+				Groups:     []string{"TACACS-SERVERS"},
+				Local:      true,
+				Other:      []string{},
+			},
+			count:      5,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input: "show.aaa.authentication.2",
+			exp: &AAAAuthentication{
+				Service:    "default",
+				Configured: true,
+				Groups:     []string{"tacacs+", "radius"},
+				Local:      true,
+				Other:      []string{},
+			},
+			count:      9,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewAAAAuthenticationFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestParseShowAAAAuthenticationStates(t *testing.T) {
+	fp := "../../assets/requests/resp.show.aaa.authentication.2.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	items, err := NewAAAAuthenticationFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	exp := &AAAAuthentication{
+		Service: "mschap",
+		Groups:  []string{},
+		Other:   []string{"disabled"},
+	}
+	if !reflect.DeepEqual(exp, items[5]) {
+		t.Fatalf("unexpected mschap authentication: %v", items[5])
+	}
+}
+
+func TestParseShowTACACSServerJsonOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.show.tacacs-server.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	servers, err := NewTACACSServersFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	exp := &TACACSServers{
+		Timeout:         5 * time.Second,
+		SourceInterface: "mgmt0",
+		Servers: []TACACSServer{
+			{Address: "10.0.0.10", Port: 49, Timeout: 5 * time.Second},
+			{Address: "10.0.0.11", Port: 49, Timeout: 10 * time.Second},
+		},
+	}
+	if !reflect.DeepEqual(exp, servers) {
+		t.Fatalf("unexpected TACACS+ servers: %v", servers)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const accountingTimestampFormat = "Mon Jan _2 15:04:05 2006"
+
+var (
+	accountingLogRegex    = regexp.MustCompile(`^(\w{3} \w{3}\s+\d{1,2} \d{2}:\d{2}:\d{2} \d{4}):type=([^:]*):id=(.*?):user=([^:]*):cmd=(.*)$`)
+	accountingStatusRegex = regexp.MustCompile(`^(.*?)\s*\((SUCCESS|FAILURE|REDIRECT)\)$`)
+)
+
+// AccountingLogEntry is an entry of the accounting log. The information
+// in the structure is from the output of "show accounting log" command.
+type AccountingLogEntry struct {
+	Timestamp time.Time `json:"timestamp" xml:"timestamp"`
+	Type      string    `json:"type" xml:"type"`
+	Session   string    `json:"session" xml:"session"`
+	User      string    `json:"user" xml:"user"`
+	Command   string    `json:"command" xml:"command"`
+	Status    string    `json:"status" xml:"status"`
+}
+
+// NewAccountingLogFromString returns AccountingLogEntry instances from an
+// input string.
+func NewAccountingLogFromString(s string) ([]*AccountingLogEntry, error) {
+	return NewAccountingLogFromBytes([]byte(s))
+}
+
+// NewAccountingLogFromBytes returns AccountingLogEntry instances from an
+// input byte array. The timestamps are interpreted as UTC.
+func NewAccountingLogFromBytes(s []byte) ([]*AccountingLogEntry, error) {
+	return newAccountingLogFromBytes(s, time.UTC)
+}
+
+func newAccountingLogFromBytes(s []byte, loc *time.Location) ([]*AccountingLogEntry, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseAccountingLog(resp.Result.Outputs.Output.Body, loc), nil
+}
+
+// parseAccountingLog parses accounting log lines, e.g.
+//
+//	Tue Dec 18 20:55:02 2018:type=update:id=10.0.0.5@pts/0:user=admin:cmd=configure terminal ; hostname ny-sw01 (SUCCESS)
+func parseAccountingLog(s string, loc *time.Location) []*AccountingLogEntry {
+	entries := []*AccountingLogEntry{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, " \r")
+		m := accountingLogRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		ts, err := time.ParseInLocation(accountingTimestampFormat, m[1], loc)
+		if err != nil {
+			continue
+		}
+		entry := &AccountingLogEntry{
+			Timestamp: ts,
+			Type:      m[2],
+			Session:   m[3],
+			User:      m[4],
+			Command:   m[5],
+		}
+		if sm := accountingStatusRegex.FindStringSubmatch(entry.Command); sm != nil {
+			entry.Command = sm[1]
+			entry.Status = sm[2]
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShowAccountingLogOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *AccountingLogEntry
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.accounting.log.1",
+			exp: &AccountingLogEntry{
+				Timestamp: time.Date(2018, time.December, 18, 20, 55, 2, 0, time.UTC),
+				Type:      "start",
+				Session:   "10.0.0.5@pts/0",
+				User:      "admin",
+			},
+			count:      5,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "error.invalid.params",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewAccountingLogFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestParseAccountingLogCommands(t *testing.T) {
+	fp := "../../assets/requests/resp.show.accounting.log.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	entries, err := NewAccountingLogFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	for i, exp := range []struct {
+		command string
+		status  string
+	}{
+		{"", ""},
+		{"configure terminal ; interface Ethernet1/1 ; shutdown", "SUCCESS"},
+		{"configure terminal ; vlan 5000", "FAILURE"},
+		{"configure terminal ; interface Ethernet1/2 ; description uplink", "SUCCESS"},
+		{"shell terminated because of telnet closed", ""},
+	} {
+		if entries[i].Command != exp.command || entries[i].Status != exp.status {
+			t.Fatalf("entry %d: unexpected command %q, status %q", i, entries[i].Command, entries[i].Status)
+		}
+	}
+}
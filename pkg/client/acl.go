@@ -0,0 +1,388 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"net"
+	"strings"
+)
+
+type accessListResponse struct {
+	ID      uint64                   `json:"id" xml:"id"`
+	Version string                   `json:"jsonrpc" xml:"jsonrpc"`
+	Result  accessListResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError    `json:"error,omitempty" xml:"error"`
+}
+
+type accessListResponseResult struct {
+	Body accessListResponseResultBody `json:"body" xml:"body"`
+}
+
+type accessListResponseResultBody struct {
+	AccessListTable []struct {
+		AccessListRow []struct {
+			Type       string `json:"op_ip_ipv6_mac" xml:"op_ip_ipv6_mac"`
+			Name       string `json:"acl_name" xml:"acl_name"`
+			Statistics string `json:"statistics" xml:"statistics"`
+			EntryTable []struct {
+				EntryRow []accessListResponseResultBodyEntryRow `json:"ROW_seqno" xml:"ROW_seqno"`
+			} `json:"TABLE_seqno" xml:"TABLE_seqno"`
+		} `json:"ROW_ip_ipv6_mac" xml:"ROW_ip_ipv6_mac"`
+	} `json:"TABLE_ip_ipv6_mac" xml:"TABLE_ip_ipv6_mac"`
+}
+
+type accessListResponseResultBodyEntryRow struct {
+	Sequence                int    `json:"seqno" xml:"seqno"`
+	Action                  string `json:"permitdeny" xml:"permitdeny"`
+	Remark                  string `json:"remark" xml:"remark"`
+	Protocol                string `json:"proto_str" xml:"proto_str"`
+	SourceAny               string `json:"src_any" xml:"src_any"`
+	SourcePrefix            string `json:"src_ip_prefix" xml:"src_ip_prefix"`
+	SourceMacAddress        string `json:"src_mac_addr" xml:"src_mac_addr"`
+	SourceMacMask           string `json:"src_mac_mask" xml:"src_mac_mask"`
+	SourceGroup             string `json:"src_addrgrp" xml:"src_addrgrp"`
+	SourcePortOperator      string `json:"src_port_op" xml:"src_port_op"`
+	SourcePort1             string `json:"src_port1_str" xml:"src_port1_str"`
+	SourcePort1Number       int    `json:"src_port1_num" xml:"src_port1_num"`
+	SourcePort2             string `json:"src_port2_str" xml:"src_port2_str"`
+	SourcePort2Number       int    `json:"src_port2_num" xml:"src_port2_num"`
+	SourcePortGroup         string `json:"src_portgrp" xml:"src_portgrp"`
+	DestinationAny          string `json:"dest_any" xml:"dest_any"`
+	DestinationPrefix       string `json:"dest_ip_prefix" xml:"dest_ip_prefix"`
+	DestinationMacAddress   string `json:"dest_mac_addr" xml:"dest_mac_addr"`
+	DestinationMacMask      string `json:"dest_mac_mask" xml:"dest_mac_mask"`
+	DestinationGroup        string `json:"dest_addrgrp" xml:"dest_addrgrp"`
+	DestinationPortOperator string `json:"dest_port_op" xml:"dest_port_op"`
+	DestinationPort1        string `json:"dest_port1_str" xml:"dest_port1_str"`
+	DestinationPort1Number  int    `json:"dest_port1_num" xml:"dest_port1_num"`
+	DestinationPort2        string `json:"dest_port2_str" xml:"dest_port2_str"`
+	DestinationPort2Number  int    `json:"dest_port2_num" xml:"dest_port2_num"`
+	DestinationPortGroup    string `json:"dest_portgrp" xml:"dest_portgrp"`
+	Established             string `json:"established" xml:"established"`
+	Fragments               string `json:"fragments" xml:"fragments"`
+	DSCP                    string `json:"dscp" xml:"dscp"`
+	Precedence              string `json:"precedence" xml:"precedence"`
+	TOS                     string `json:"tos" xml:"tos"`
+	TTL                     string `json:"ttl" xml:"ttl"`
+	TCPFlagAck              string `json:"ack" xml:"ack"`
+	TCPFlagFin              string `json:"fin" xml:"fin"`
+	TCPFlagPsh              string `json:"psh" xml:"psh"`
+	TCPFlagRst              string `json:"rst" xml:"rst"`
+	TCPFlagSyn              string `json:"syn" xml:"syn"`
+	TCPFlagUrg              string `json:"urg" xml:"urg"`
+	Log                     string `json:"log" xml:"log"`
+	Matches                 uint64 `json:"totalmatches" xml:"totalmatches"`
+}
+
+// AccessListPort is the port match condition of AccessListEntry. Ports
+// hold the port names or numbers as displayed, while Numbers hold their
+// numeric values.
+type AccessListPort struct {
+	Operator string   `json:"operator" xml:"operator"`
+	Ports    []string `json:"ports" xml:"ports"`
+	Numbers  []int    `json:"numbers" xml:"numbers"`
+	Group    string   `json:"group" xml:"group"`
+}
+
+// AccessListEntry is an access control entry (ACE) of AccessList. The
+// Qualifiers are the other match conditions of the entry, e.g.
+// "established", "fragments" or "dscp af11".
+type AccessListEntry struct {
+	Sequence         int            `json:"sequence" xml:"sequence"`
+	Action           string         `json:"action" xml:"action"`
+	Remark           string         `json:"remark" xml:"remark"`
+	Protocol         string         `json:"protocol" xml:"protocol"`
+	Source           string         `json:"source" xml:"source"`
+	SourceGroup      string         `json:"source_group" xml:"source_group"`
+	SourcePort       AccessListPort `json:"source_port" xml:"source_port"`
+	Destination      string         `json:"destination" xml:"destination"`
+	DestinationGroup string         `json:"destination_group" xml:"destination_group"`
+	DestinationPort  AccessListPort `json:"destination_port" xml:"destination_port"`
+	Qualifiers       []string       `json:"qualifiers,omitempty" xml:"qualifiers,omitempty"`
+	Log              bool           `json:"log" xml:"log"`
+	Matches          uint64         `json:"matches" xml:"matches"`
+}
+
+// AccessList contains access list information. The information in the
+// structure is from the output of "show access-lists" command.
+type AccessList struct {
+	Name       string            `json:"name" xml:"name"`
+	Type       string            `json:"type" xml:"type"`
+	Statistics bool              `json:"statistics" xml:"statistics"`
+	Entries    []AccessListEntry `json:"entries" xml:"entries"`
+}
+
+// IsRemark returns true when the entry is a remark.
+func (e *AccessListEntry) IsRemark() bool {
+	return e.Action == "remark"
+}
+
+// UnusedEntries returns the entries of the access list without any matches.
+// The result is meaningful only when the statistics are enabled for the
+// access list.
+func (acl *AccessList) UnusedEntries() []AccessListEntry {
+	var entries []AccessListEntry
+	for _, e := range acl.Entries {
+		if e.IsRemark() {
+			continue
+		}
+		if e.Matches == 0 {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// ShadowedEntries returns the entries of the access list that can never
+// match, because a preceding entry matches all of their traffic.
+func (acl *AccessList) ShadowedEntries() []AccessListEntry {
+	var entries []AccessListEntry
+	for i, e := range acl.Entries {
+		if e.IsRemark() {
+			continue
+		}
+		for _, p := range acl.Entries[:i] {
+			if p.IsRemark() {
+				continue
+			}
+			if p.Covers(&e) {
+				entries = append(entries, e)
+				break
+			}
+		}
+	}
+	return entries
+}
+
+// Covers returns true when the entry matches all the traffic matched
+// by the other entry. The qualifiers of the entry, e.g. "established",
+// are not compared, and an entry with any of them covers no other entry.
+func (e *AccessListEntry) Covers(o *AccessListEntry) bool {
+	if e.IsRemark() || o.IsRemark() {
+		return false
+	}
+	if len(e.Qualifiers) > 0 {
+		return false
+	}
+	switch e.Protocol {
+	case "ip", "ipv6", o.Protocol:
+	default:
+		return false
+	}
+	if !aclAddressCovers(e.Source, e.SourceGroup, o.Source, o.SourceGroup) {
+		return false
+	}
+	if !aclAddressCovers(e.Destination, e.DestinationGroup, o.Destination, o.DestinationGroup) {
+		return false
+	}
+	if !e.SourcePort.Covers(&o.SourcePort) {
+		return false
+	}
+	if !e.DestinationPort.Covers(&o.DestinationPort) {
+		return false
+	}
+	return true
+}
+
+func aclAddressCovers(addr, group, otherAddr, otherGroup string) bool {
+	if group != "" || otherGroup != "" {
+		return group == otherGroup
+	}
+	if addr == "any" || addr == otherAddr {
+		return true
+	}
+	if otherAddr == "any" {
+		return false
+	}
+	_, network, err := net.ParseCIDR(addr)
+	if err != nil {
+		return false
+	}
+	otherIP, otherNetwork, err := net.ParseCIDR(otherAddr)
+	if err != nil {
+		return false
+	}
+	size, _ := network.Mask.Size()
+	otherSize, _ := otherNetwork.Mask.Size()
+	return size <= otherSize && network.Contains(otherIP)
+}
+
+// Covers returns true when the port condition matches all the ports
+// matched by the other port condition.
+func (p *AccessListPort) Covers(o *AccessListPort) bool {
+	if p.Operator == "" && p.Group == "" {
+		return true
+	}
+	if p.Group != "" || o.Group != "" {
+		return p.Group == o.Group
+	}
+	low, high, ok := p.bounds()
+	if !ok {
+		return p.Operator == o.Operator && fmt.Sprint(p.Numbers) == fmt.Sprint(o.Numbers)
+	}
+	otherLow, otherHigh, ok := o.bounds()
+	if !ok {
+		return false
+	}
+	return low <= otherLow && otherHigh <= high
+}
+
+func (p *AccessListPort) bounds() (int, int, bool) {
+	switch {
+	case p.Operator == "":
+		return 0, 65535, true
+	case p.Operator == "eq" && len(p.Numbers) == 1:
+		return p.Numbers[0], p.Numbers[0], true
+	case p.Operator == "gt" && len(p.Numbers) == 1:
+		return p.Numbers[0] + 1, 65535, true
+	case p.Operator == "lt" && len(p.Numbers) == 1:
+		return 0, p.Numbers[0] - 1, true
+	case p.Operator == "range" && len(p.Numbers) == 2:
+		return p.Numbers[0], p.Numbers[1], true
+	}
+	return 0, 0, false
+}
+
+func newAccessListPort(op, group, port1, port2 string, num1, num2 int) AccessListPort {
+	p := AccessListPort{
+		Operator: op,
+		Group:    group,
+		Ports:    []string{},
+		Numbers:  []int{},
+	}
+	if op == "" {
+		return p
+	}
+	if port1 == "" {
+		port1 = fmt.Sprintf("%d", num1)
+	}
+	p.Ports = append(p.Ports, port1)
+	p.Numbers = append(p.Numbers, num1)
+	if op == "range" {
+		if port2 == "" {
+			port2 = fmt.Sprintf("%d", num2)
+		}
+		p.Ports = append(p.Ports, port2)
+		p.Numbers = append(p.Numbers, num2)
+	}
+	return p
+}
+
+func newAccessListQualifiers(re *accessListResponseResultBodyEntryRow) []string {
+	var qualifiers []string
+	for _, q := range []struct {
+		name, value string
+	}{
+		{"dscp", re.DSCP},
+		{"precedence", re.Precedence},
+		{"tos", re.TOS},
+		{"ttl", re.TTL},
+	} {
+		if q.value != "" {
+			qualifiers = append(qualifiers, q.name+" "+q.value)
+		}
+	}
+	for _, q := range []struct {
+		name, value string
+	}{
+		{"established", re.Established},
+		{"fragments", re.Fragments},
+		{"ack", re.TCPFlagAck},
+		{"fin", re.TCPFlagFin},
+		{"psh", re.TCPFlagPsh},
+		{"rst", re.TCPFlagRst},
+		{"syn", re.TCPFlagSyn},
+		{"urg", re.TCPFlagUrg},
+	} {
+		if q.value != "" {
+			qualifiers = append(qualifiers, q.name)
+		}
+	}
+	return qualifiers
+}
+
+func newAccessListAddress(any, prefix, macAddr, macMask string) string {
+	switch {
+	case any != "":
+		return "any"
+	case prefix != "":
+		return prefix
+	case macAddr != "":
+		return strings.TrimSpace(macAddr + " " + macMask)
+	}
+	return ""
+}
+
+// NewAccessListsFromString returns AccessList instances from an input string.
+func NewAccessListsFromString(s string) ([]*AccessList, error) {
+	return NewAccessListsFromBytes([]byte(s))
+}
+
+// NewAccessListsFromBytes returns AccessList instances from an input byte array.
+func NewAccessListsFromBytes(s []byte) ([]*AccessList, error) {
+	var acls []*AccessList
+	resp := &accessListResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.AccessListTable {
+		for _, r := range t.AccessListRow {
+			acl := &AccessList{
+				Name:       r.Name,
+				Type:       r.Type,
+				Statistics: r.Statistics == "enable",
+				Entries:    []AccessListEntry{},
+			}
+			for _, te := range r.EntryTable {
+				for _, re := range te.EntryRow {
+					e := AccessListEntry{
+						Sequence:         re.Sequence,
+						Action:           re.Action,
+						Remark:           re.Remark,
+						Protocol:         re.Protocol,
+						Source:           newAccessListAddress(re.SourceAny, re.SourcePrefix, re.SourceMacAddress, re.SourceMacMask),
+						SourceGroup:      re.SourceGroup,
+						SourcePort:       newAccessListPort(re.SourcePortOperator, re.SourcePortGroup, re.SourcePort1, re.SourcePort2, re.SourcePort1Number, re.SourcePort2Number),
+						Destination:      newAccessListAddress(re.DestinationAny, re.DestinationPrefix, re.DestinationMacAddress, re.DestinationMacMask),
+						DestinationGroup: re.DestinationGroup,
+						DestinationPort:  newAccessListPort(re.DestinationPortOperator, re.DestinationPortGroup, re.DestinationPort1, re.DestinationPort2, re.DestinationPort1Number, re.DestinationPort2Number),
+						Qualifiers:       newAccessListQualifiers(&re),
+						Log:              re.Log != "",
+						Matches:          re.Matches,
+					}
+					if e.Remark != "" && e.Action == "" {
+						e.Action = "remark"
+					}
+					if e.Protocol == "" && acl.Type != "mac" && !e.IsRemark() {
+						e.Protocol = acl.Type
+					}
+					acl.Entries = append(acl.Entries, e)
+				}
+			}
+			acls = append(acls, acl)
+		}
+	}
+	return acls, nil
+}
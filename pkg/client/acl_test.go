@@ -0,0 +1,212 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestParseShowAccessListsJsonOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *AccessList
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.access-lists.1",
+			exp: &AccessList{
+				Name:       "MGMT-IN",
+				Type:       "ip",
+				Statistics: true,
+				Entries: []AccessListEntry{
+					{
+						Sequence:        5,
+						Action:          "remark",
+						Remark:          "allow ssh from jump hosts",
+						SourcePort:      AccessListPort{Ports: []string{}, Numbers: []int{}},
+						DestinationPort: AccessListPort{Ports: []string{}, Numbers: []int{}},
+					},
+					{
+						Sequence:        10,
+						Action:          "permit",
+						Protocol:        "tcp",
+						Source:          "10.0.0.0/8",
+						SourcePort:      AccessListPort{Ports: []string{}, Numbers: []int{}},
+						Destination:     "any",
+						DestinationPort: AccessListPort{Operator: "eq", Ports: []string{"22"}, Numbers: []int{22}},
+						Matches:         1024,
+					},
+					{
+						Sequence:        20,
+						Action:          "permit",
+						Protocol:        "tcp",
+						Source:          "10.1.1.0/24",
+						SourcePort:      AccessListPort{Ports: []string{}, Numbers: []int{}},
+						Destination:     "any",
+						DestinationPort: AccessListPort{Operator: "eq", Ports: []string{"22"}, Numbers: []int{22}},
+					},
+					{
+						Sequence:        30,
+						Action:          "permit",
+						Protocol:        "tcp",
+						SourceGroup:     "MONITORING",
+						SourcePort:      AccessListPort{Ports: []string{}, Numbers: []int{}},
+						Destination:     "any",
+						DestinationPort: AccessListPort{Group: "WEB-PORTS", Ports: []string{}, Numbers: []int{}},
+						Matches:         77,
+					},
+					{
+						Sequence:        40,
+						Action:          "deny",
+						Protocol:        "ip",
+						Source:          "any",
+						SourcePort:      AccessListPort{Ports: []string{}, Numbers: []int{}},
+						Destination:     "any",
+						DestinationPort: AccessListPort{Ports: []string{}, Numbers: []int{}},
+						Log:             true,
+						Matches:         12,
+					},
+				},
+			},
+			count:      3,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "error.invalid.params",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewAccessListsFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestAccessListAnalysis(t *testing.T) {
+	fp := "../../assets/requests/resp.show.access-lists.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	acls, err := NewAccessListsFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	var sequences []int
+	for _, e := range acls[0].UnusedEntries() {
+		sequences = append(sequences, e.Sequence)
+	}
+	if !reflect.DeepEqual(sequences, []int{20}) {
+		t.Fatalf("unexpected unused entries: %v", sequences)
+	}
+	sequences = nil
+	for _, e := range acls[0].ShadowedEntries() {
+		sequences = append(sequences, e.Sequence)
+	}
+	if !reflect.DeepEqual(sequences, []int{20}) {
+		t.Fatalf("unexpected shadowed entries: %v", sequences)
+	}
+	if len(acls[1].ShadowedEntries()) != 0 {
+		t.Fatalf("unexpected shadowed entries in %s", acls[1].Name)
+	}
+	wide := &AccessListPort{Operator: "range", Numbers: []int{1000, 3000}}
+	narrow := &AccessListPort{Operator: "eq", Numbers: []int{2048}}
+	if !wide.Covers(narrow) || narrow.Covers(wide) {
+		t.Fatalf("unexpected port coverage: %v %v", wide, narrow)
+	}
+
+	fp = "../../assets/requests/resp.show.access-lists.2.json"
+	content, err = ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	acls, err = NewAccessListsFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	qualifiers := map[int][]string{}
+	for _, e := range acls[0].Entries {
+		if len(e.Qualifiers) > 0 {
+			qualifiers[e.Sequence] = e.Qualifiers
+		}
+	}
+	expQualifiers := map[int][]string{
+		10: {"established"},
+		30: {"dscp af11"},
+		50: {"fragments"},
+		60: {"syn"},
+	}
+	if !reflect.DeepEqual(qualifiers, expQualifiers) {
+		t.Fatalf("unexpected qualifiers: %v", qualifiers)
+	}
+	// the entries with qualifiers, e.g. "permit tcp any any established",
+	// do not shadow the following entries.
+	sequences = nil
+	for _, e := range acls[0].ShadowedEntries() {
+		sequences = append(sequences, e.Sequence)
+	}
+	if !reflect.DeepEqual(sequences, []int{60, 70}) {
+		t.Fatalf("unexpected shadowed entries: %v", sequences)
+	}
+}
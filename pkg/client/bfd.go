@@ -0,0 +1,132 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+	"time"
+)
+
+type bfdNeighborResponse struct {
+	ID      uint64                    `json:"id" xml:"id"`
+	Version string                    `json:"jsonrpc" xml:"jsonrpc"`
+	Result  bfdNeighborResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError     `json:"error,omitempty" xml:"error"`
+}
+
+type bfdNeighborResponseResult struct {
+	Body bfdNeighborResponseResultBody `json:"body" xml:"body"`
+}
+
+type bfdNeighborResponseResultBody struct {
+	NeighborTable []struct {
+		NeighborRow []bfdNeighborResponseResultBodyNeighborRow `json:"ROW_bfdNeighbor" xml:"ROW_bfdNeighbor"`
+	} `json:"TABLE_bfdNeighbor" xml:"TABLE_bfdNeighbor"`
+}
+
+type bfdNeighborResponseResultBodyNeighborRow struct {
+	SourceAddress       string `json:"src_ip_addr" xml:"src_ip_addr"`
+	DestinationAddress  string `json:"dest_ip_addr" xml:"dest_ip_addr"`
+	LocalDiscriminator  uint64 `json:"ld" xml:"ld"`
+	RemoteDiscriminator uint64 `json:"rd" xml:"rd"`
+	RemoteState         string `json:"rh_rs" xml:"rh_rs"`
+	Holddown            int64  `json:"holddown" xml:"holddown"`
+	Multiplier          int    `json:"cur_detect_mult" xml:"cur_detect_mult"`
+	State               string `json:"state" xml:"state"`
+	Interface           string `json:"intf" xml:"intf"`
+	Vrf                 string `json:"vrf_name" xml:"vrf_name"`
+	MinTxInterval       int64  `json:"min_tx" xml:"min_tx"`
+	MinRxInterval       int64  `json:"min_rx" xml:"min_rx"`
+	RegisteredProtocols string `json:"registered_protos" xml:"registered_protos"`
+	Uptime              int64  `json:"up_time" xml:"up_time"`
+	DownCount           int    `json:"down_count" xml:"down_count"`
+	LastDownReason      string `json:"last_down_reason" xml:"last_down_reason"`
+}
+
+// BFDNeighbor contains BFD session information. The information in the
+// structure is from the output of "show bfd neighbors detail" command.
+type BFDNeighbor struct {
+	Vrf                 string        `json:"vrf" xml:"vrf"`
+	Interface           string        `json:"interface" xml:"interface"`
+	SourceAddress       string        `json:"source_address" xml:"source_address"`
+	DestinationAddress  string        `json:"destination_address" xml:"destination_address"`
+	LocalDiscriminator  uint64        `json:"local_discriminator" xml:"local_discriminator"`
+	RemoteDiscriminator uint64        `json:"remote_discriminator" xml:"remote_discriminator"`
+	State               string        `json:"state" xml:"state"`
+	RemoteState         string        `json:"remote_state" xml:"remote_state"`
+	Holddown            time.Duration `json:"holddown" xml:"holddown"`
+	MinTxInterval       time.Duration `json:"min_tx_interval" xml:"min_tx_interval"`
+	MinRxInterval       time.Duration `json:"min_rx_interval" xml:"min_rx_interval"`
+	Multiplier          int           `json:"multiplier" xml:"multiplier"`
+	RegisteredProtocols []string      `json:"registered_protocols" xml:"registered_protocols"`
+	Uptime              time.Duration `json:"uptime" xml:"uptime"`
+	DownCount           int           `json:"down_count" xml:"down_count"`
+	LastDownReason      string        `json:"last_down_reason" xml:"last_down_reason"`
+}
+
+// IsUp returns true when the BFD session is up.
+func (n *BFDNeighbor) IsUp() bool {
+	return strings.EqualFold(n.State, "up")
+}
+
+// NewBFDNeighborsFromString returns BFDNeighbor instances from an input string.
+func NewBFDNeighborsFromString(s string) ([]*BFDNeighbor, error) {
+	return NewBFDNeighborsFromBytes([]byte(s))
+}
+
+// NewBFDNeighborsFromBytes returns BFDNeighbor instances from an input byte array.
+func NewBFDNeighborsFromBytes(s []byte) ([]*BFDNeighbor, error) {
+	var neighbors []*BFDNeighbor
+	resp := &bfdNeighborResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.NeighborTable {
+		for _, r := range t.NeighborRow {
+			n := &BFDNeighbor{
+				Vrf:                 r.Vrf,
+				Interface:           r.Interface,
+				SourceAddress:       r.SourceAddress,
+				DestinationAddress:  r.DestinationAddress,
+				LocalDiscriminator:  r.LocalDiscriminator,
+				RemoteDiscriminator: r.RemoteDiscriminator,
+				State:               r.State,
+				RemoteState:         r.RemoteState,
+				// the holddown timer is in milliseconds, while the
+				// negotiated intervals are in microseconds.
+				Holddown:            time.Duration(r.Holddown) * time.Millisecond,
+				MinTxInterval:       time.Duration(r.MinTxInterval) * time.Microsecond,
+				MinRxInterval:       time.Duration(r.MinRxInterval) * time.Microsecond,
+				Multiplier:          r.Multiplier,
+				RegisteredProtocols: strings.Fields(r.RegisteredProtocols),
+				Uptime:              time.Duration(r.Uptime) * time.Second,
+				DownCount:           r.DownCount,
+				LastDownReason:      r.LastDownReason,
+			}
+			neighbors = append(neighbors, n)
+		}
+	}
+	return neighbors, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BootImages are the images a supervisor boots from.
+type BootImages struct {
+	Kickstart string `json:"kickstart" xml:"kickstart"`
+	System    string `json:"system" xml:"system"`
+}
+
+// BootVariables contains the boot variables of a supervisor. The
+// information in the structure is from the output of "show boot" command.
+// The platforms without a kickstart image, e.g. Nexus 9000, report their
+// "NXOS variable" as the system image.
+type BootVariables struct {
+	Supervisor string     `json:"supervisor" xml:"supervisor"`
+	Current    BootImages `json:"current" xml:"current"`
+	Next       BootImages `json:"next" xml:"next"`
+}
+
+// IsChanged returns true when the supervisor boots from different images
+// on the next reload.
+func (b *BootVariables) IsChanged() bool {
+	return b.Current != b.Next
+}
+
+// NewBootVariablesFromString returns BootVariables instances from an input
+// string.
+func NewBootVariablesFromString(s string) ([]*BootVariables, error) {
+	return NewBootVariablesFromBytes([]byte(s))
+}
+
+// NewBootVariablesFromBytes returns BootVariables instances from an input
+// byte array.
+func NewBootVariablesFromBytes(s []byte) ([]*BootVariables, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseBootVariables(resp.Result.Outputs.Output.Body)
+}
+
+// parseBootVariables parses the text output of "show boot", e.g.
+//
+//	Current Boot Variables:
+//
+//	sup-1
+//	kickstart variable = bootflash:/n7000-s2-kickstart.8.2.1.bin
+//	system variable = bootflash:/n7000-s2-dk9.8.2.1.bin
+//
+//	Boot Variables on next reload:
+//
+//	sup-1
+//	kickstart variable = bootflash:/n7000-s2-kickstart.8.2.2.bin
+//	system variable = bootflash:/n7000-s2-dk9.8.2.2.bin
+func parseBootVariables(s string) ([]*BootVariables, error) {
+	var supervisors []*BootVariables
+	var images *BootImages
+	next := false
+	getSupervisor := func(name string) *BootVariables {
+		for _, sup := range supervisors {
+			if sup.Supervisor == name {
+				return sup
+			}
+		}
+		sup := &BootVariables{Supervisor: name}
+		supervisors = append(supervisors, sup)
+		return sup
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Current Boot Variables"):
+			next, images = false, nil
+		case strings.HasPrefix(line, "Boot Variables on next reload"):
+			next, images = true, nil
+		case strings.HasPrefix(line, "sup-"):
+			sup := getSupervisor(strings.Fields(line)[0])
+			if next {
+				images = &sup.Next
+			} else {
+				images = &sup.Current
+			}
+		case images != nil && strings.Contains(line, " variable = "):
+			i := strings.Index(line, " variable = ")
+			value := strings.TrimSpace(line[i+len(" variable = "):])
+			switch strings.ToLower(line[:i]) {
+			case "kickstart":
+				images.Kickstart = value
+			case "system", "nxos":
+				images.System = value
+			}
+		}
+	}
+	if len(supervisors) == 0 {
+		return nil, fmt.Errorf("no boot variables found: %s", s)
+	}
+	return supervisors, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestParseShowBootOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *BootVariables
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.boot.1",
+			exp: &BootVariables{
+				Supervisor: "sup-1",
+				Current: BootImages{
+					Kickstart: "bootflash:/n7000-s2-kickstart.8.2.1.bin",
+					System:    "bootflash:/n7000-s2-dk9.8.2.1.bin",
+				},
+				Next: BootImages{
+					Kickstart: "bootflash:/n7000-s2-kickstart.8.2.2.bin",
+					System:    "bootflash:/n7000-s2-dk9.8.2.2.bin",
+				},
+			},
+			count:      2,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input: "show.boot.2",
+			exp: &BootVariables{
+				Supervisor: "sup-1",
+				Current: BootImages{
+					System: "bootflash:/nxos.7.0.3.I7.5a.bin",
+				},
+				Next: BootImages{
+					System: "bootflash:/nxos.7.0.3.I7.5a.bin",
+				},
+			},
+			count:      1,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "show.install.all.impact.1",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewBootVariablesFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
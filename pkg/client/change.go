@@ -0,0 +1,251 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The states of ChangeTransaction.
+const (
+	ChangePending     = "pending"
+	ChangeConfirmed   = "confirmed"
+	ChangeRollingBack = "rolling-back"
+	ChangeRolledBack  = "rolled-back"
+	ChangeFailed      = "failed"
+)
+
+// ChangeCheck verifies the device after a change, e.g. that the BGP peers
+// are still established. An error fails the change.
+type ChangeCheck func(cli *Client) error
+
+// ChangeRequest is a change applied by ApplyChange. When ConfirmTimeout is
+// zero, the change is confirmed once the checks pass. Otherwise, the
+// change is rolled back unless confirmed within the timeout. The
+// Checkpoint defaults to "nxapi-change-<unix time in nanoseconds>", and
+// the RollbackMode to RollbackAtomic.
+type ChangeRequest struct {
+	Commands       []string
+	Checks         []ChangeCheck
+	Checkpoint     string
+	ConfirmTimeout time.Duration
+	RollbackMode   string
+}
+
+// ChangeTransaction is a change applied to the running configuration,
+// with a checkpoint to roll the change back to.
+type ChangeTransaction struct {
+	Checkpoint string
+	Responses  []JSONRPCResponse
+
+	cli    *Client
+	mode   string
+	lock   sync.Mutex
+	state  string
+	err    error
+	result *RollbackResult
+	timer  *time.Timer
+	done   chan struct{}
+}
+
+// ApplyChange creates a checkpoint, applies the commands of a change and
+// runs its checks. When the commands or the checks fail, the running
+// configuration is rolled back to the checkpoint, and the error is
+// returned along with the transaction. The checkpoint is deleted once the
+// change is confirmed or rolled back.
+func (cli *Client) ApplyChange(r *ChangeRequest) (*ChangeTransaction, error) {
+	if len(r.Commands) == 0 {
+		return nil, fmt.Errorf("empty input")
+	}
+	tx := &ChangeTransaction{
+		Checkpoint: r.Checkpoint,
+		cli:        cli,
+		mode:       r.RollbackMode,
+		state:      ChangePending,
+		done:       make(chan struct{}),
+	}
+	switch tx.mode {
+	case "":
+		tx.mode = RollbackAtomic
+	case RollbackAtomic, RollbackBestEffort, RollbackStopAtFirstFailure:
+	default:
+		return nil, fmt.Errorf("unsupported rollback mode: %s", tx.mode)
+	}
+	if tx.Checkpoint == "" {
+		tx.Checkpoint = fmt.Sprintf("nxapi-change-%d", time.Now().UnixNano())
+	}
+	if err := cli.CreateCheckpoint(tx.Checkpoint); err != nil {
+		return nil, fmt.Errorf("failed creating checkpoint %s: %s", tx.Checkpoint, err)
+	}
+
+	resp, err := cli.Configure(r.Commands)
+	tx.Responses = resp
+	if err != nil {
+		return tx, tx.rollback(fmt.Errorf("failed applying change: %s", err))
+	}
+	if err := jsonRPCResponsesError(r.Commands, resp); err != nil {
+		return tx, tx.rollback(err)
+	}
+	for i, check := range r.Checks {
+		if err := check(cli); err != nil {
+			return tx, tx.rollback(fmt.Errorf("check %d failed: %s", i+1, err))
+		}
+	}
+
+	if r.ConfirmTimeout <= 0 {
+		return tx, tx.Confirm()
+	}
+	tx.lock.Lock()
+	tx.timer = time.AfterFunc(r.ConfirmTimeout, func() {
+		if tx.begin() {
+			tx.rollback(fmt.Errorf("change not confirmed within %s", r.ConfirmTimeout))
+		}
+	})
+	tx.lock.Unlock()
+	return tx, nil
+}
+
+// State returns the state of the change, e.g. ChangePending.
+func (tx *ChangeTransaction) State() string {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	return tx.state
+}
+
+// RollbackResult returns the result of the rollback of the change, or nil
+// when the change was not rolled back.
+func (tx *ChangeTransaction) RollbackResult() *RollbackResult {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	return tx.result
+}
+
+// Confirm keeps a pending change and deletes its checkpoint.
+func (tx *ChangeTransaction) Confirm() error {
+	tx.lock.Lock()
+	if tx.state != ChangePending {
+		state := tx.state
+		tx.lock.Unlock()
+		return fmt.Errorf("change is %s", state)
+	}
+	if tx.timer != nil {
+		tx.timer.Stop()
+	}
+	tx.state = ChangeConfirmed
+	close(tx.done)
+	tx.lock.Unlock()
+	return tx.cli.DeleteCheckpoint(tx.Checkpoint)
+}
+
+// Abort rolls a pending change back to its checkpoint.
+func (tx *ChangeTransaction) Abort() error {
+	if !tx.begin() {
+		return fmt.Errorf("change is %s", tx.State())
+	}
+	return tx.rollback(fmt.Errorf("change aborted"))
+}
+
+// Wait blocks until the change is confirmed or rolled back, and returns
+// the reason of the rollback.
+func (tx *ChangeTransaction) Wait() error {
+	<-tx.done
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	return tx.err
+}
+
+// begin moves a pending change to ChangeRollingBack, and returns false
+// when the change is no longer pending.
+func (tx *ChangeTransaction) begin() bool {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	if tx.state != ChangePending {
+		return false
+	}
+	if tx.timer != nil {
+		tx.timer.Stop()
+	}
+	tx.state = ChangeRollingBack
+	return true
+}
+
+// rollback rolls the change back to the checkpoint because of an error,
+// and returns the error with the outcome of the rollback. The device is
+// not called with the lock held, so that State and Wait do not block.
+func (tx *ChangeTransaction) rollback(cause error) error {
+	result, err := tx.cli.Rollback(tx.Checkpoint, tx.mode)
+	state := ChangeRolledBack
+	switch {
+	case err != nil:
+		state = ChangeFailed
+		err = fmt.Errorf("%s, rollback to checkpoint %s failed: %s", cause, tx.Checkpoint, err)
+	case !result.Success:
+		state = ChangeFailed
+		err = fmt.Errorf("%s, rollback to checkpoint %s failed: %s",
+			cause, tx.Checkpoint, strings.Join(result.Errors, "; "))
+	default:
+		err = fmt.Errorf("%s, rolled back to checkpoint %s", cause, tx.Checkpoint)
+		// the checkpoint of a failed rollback is kept for the operator.
+		if deleteErr := tx.cli.DeleteCheckpoint(tx.Checkpoint); deleteErr != nil {
+			err = fmt.Errorf("%s, failed deleting checkpoint: %s", err, deleteErr)
+		}
+	}
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+	tx.result = result
+	tx.state = state
+	tx.err = err
+	close(tx.done)
+	return tx.err
+}
+
+// jsonRPCResponsesError returns an error for the first failed command of a
+// batch, or nil when all the commands succeeded.
+func jsonRPCResponsesError(cmds []string, resp []JSONRPCResponse) error {
+	for _, r := range resp {
+		if r.Error == nil {
+			continue
+		}
+		cmd := ""
+		if r.ID > 0 && int(r.ID) <= len(cmds) {
+			cmd = cmds[r.ID-1]
+		}
+		return fmt.Errorf("failed applying command %q: %s", cmd, jsonRPCResponseMessage(r))
+	}
+	return nil
+}
+
+// jsonRPCResponseMessage returns the message of a response, e.g.
+// {"msg": "Verification Successful\n"}, or of its error.
+func jsonRPCResponseMessage(r JSONRPCResponse) string {
+	if r.Error != nil {
+		msg := r.Error.Message
+		if data := strings.TrimSpace(r.Error.Data.Msg); data != "" {
+			msg += ": " + data
+		}
+		return msg
+	}
+	result := &struct {
+		Msg string `json:"msg"`
+	}{}
+	if err := json.Unmarshal(r.Result, result); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Msg)
+}
@@ -0,0 +1,255 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// changeTestServer is NX-API server recording the checkpoint operations.
+type changeTestServer struct {
+	lock          sync.Mutex
+	configFile    string
+	deleteFile    string
+	rollbackDelay time.Duration
+	rollbacks     int
+	deleted       int
+	checkpoints   []string
+}
+
+func (s *changeTestServer) counts() (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.rollbacks, s.deleted
+}
+
+func (s *changeTestServer) handle(w http.ResponseWriter, req *http.Request) {
+	dataDir := "../../assets/requests"
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if bytes.Contains(body, []byte("rollback running-config checkpoint ")) {
+		s.lock.Lock()
+		delay := s.rollbackDelay
+		s.lock.Unlock()
+		time.Sleep(delay)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fp := fmt.Sprintf("%s/%s", dataDir, s.configFile)
+	if !bytes.Contains(body, []byte("jsonrpc")) {
+		var j *InsAPIRequest
+		if err := json.Unmarshal(body, &j); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cmd := j.Params.Input
+		switch {
+		case strings.HasPrefix(cmd, "checkpoint "):
+			s.checkpoints = append(s.checkpoints, strings.TrimPrefix(cmd, "checkpoint "))
+			fp = fmt.Sprintf("%s/%s", dataDir, "resp.checkpoint.1.json")
+		case strings.HasPrefix(cmd, "no checkpoint "):
+			s.deleted++
+			fp = fmt.Sprintf("%s/%s", dataDir, "resp.no.checkpoint.1.json")
+			if s.deleteFile != "" {
+				fp = fmt.Sprintf("%s/%s", dataDir, s.deleteFile)
+			}
+		case strings.HasPrefix(cmd, "rollback running-config checkpoint "):
+			s.rollbacks++
+			fp = fmt.Sprintf("%s/%s", dataDir, "resp.rollback.running-config.checkpoint.1.json")
+		default:
+			http.Error(w, fmt.Sprintf("Bad Request, unsupported command: %s", cmd), http.StatusBadRequest)
+			return
+		}
+	}
+	fc, err := ioutil.ReadFile(fp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(fc)
+}
+
+func newChangeTestClient(srv *changeTestServer) (*Client, func()) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ins", srv.handle)
+	server := httptest.NewServer(mux)
+
+	addr := strings.Split(server.URL, ":")
+	port, _ := strconv.Atoi(addr[2])
+	cli := NewClient()
+	cli.SetHost("127.0.0.1")
+	cli.SetPort(port)
+	cli.SetProtocol(addr[0])
+	cli.SetUsername("admin")
+	cli.SetPassword("cisco")
+	return cli, server.Close
+}
+
+func TestApplyChange(t *testing.T) {
+	srv := &changeTestServer{}
+	cli, closeServer := newChangeTestClient(srv)
+	defer closeServer()
+
+	passed := func(cli *Client) error { return nil }
+	failed := func(cli *Client) error { return fmt.Errorf("bgp peer 10.0.0.1 is down") }
+
+	for i, test := range []struct {
+		configFile   string
+		checks       []ChangeCheck
+		timeout      time.Duration
+		confirm      bool
+		shouldErr    bool
+		expState     string
+		expRollbacks int
+	}{
+		{configFile: "resp.shutdown.interface.json", checks: []ChangeCheck{passed}, expState: ChangeConfirmed},
+		{configFile: "resp.shutdown.interface.json", checks: []ChangeCheck{passed, failed}, shouldErr: true, expState: ChangeRolledBack, expRollbacks: 1},
+		{configFile: "resp.configure.error.1.json", checks: []ChangeCheck{passed}, shouldErr: true, expState: ChangeRolledBack, expRollbacks: 1},
+		{configFile: "resp.shutdown.interface.json", timeout: time.Minute, confirm: true, expState: ChangeConfirmed},
+		{configFile: "resp.shutdown.interface.json", timeout: 10 * time.Millisecond, shouldErr: true, expState: ChangeRolledBack, expRollbacks: 1},
+	} {
+		srv.lock.Lock()
+		srv.configFile = test.configFile
+		srv.rollbacks, srv.deleted = 0, 0
+		srv.lock.Unlock()
+
+		tx, err := cli.ApplyChange(&ChangeRequest{
+			Commands:       []string{"interface e1/1", "shutdown"},
+			Checks:         test.checks,
+			Checkpoint:     "before-change",
+			ConfirmTimeout: test.timeout,
+		})
+		if tx == nil {
+			t.Fatalf("test %d: unexpected error: %v", i, err)
+		}
+		if test.timeout > 0 {
+			if err != nil {
+				t.Fatalf("test %d: unexpected error: %v", i, err)
+			}
+			if tx.State() != ChangePending {
+				t.Fatalf("test %d: unexpected state: %s", i, tx.State())
+			}
+			if test.confirm {
+				err = tx.Confirm()
+			} else {
+				err = tx.Wait()
+			}
+		}
+		if (err != nil) != test.shouldErr {
+			t.Fatalf("test %d: unexpected error: %v", i, err)
+		}
+		if tx.State() != test.expState {
+			t.Fatalf("test %d: unexpected state: %s", i, tx.State())
+		}
+		rollbacks, deleted := srv.counts()
+		if rollbacks != test.expRollbacks || deleted != 1 {
+			t.Fatalf("test %d: unexpected rollbacks: %d, deleted checkpoints: %d", i, rollbacks, deleted)
+		}
+		if (tx.RollbackResult() != nil) != (test.expRollbacks > 0) {
+			t.Fatalf("test %d: unexpected rollback result: %v", i, tx.RollbackResult())
+		}
+		if err := tx.Abort(); err == nil {
+			t.Fatalf("test %d: expected error aborting finished change", i)
+		}
+		t.Logf("test %d: %s: %v", i, tx.State(), err)
+	}
+
+	if _, err := cli.ApplyChange(&ChangeRequest{Commands: []string{"vlan 10"}, RollbackMode: "never"}); err == nil {
+		t.Fatalf("expected error for unsupported rollback mode")
+	}
+	if n := len(srv.checkpoints); n != 5 {
+		t.Fatalf("unexpected number of checkpoints: %d", n)
+	}
+}
+
+func TestApplyChangeRollback(t *testing.T) {
+	srv := &changeTestServer{
+		configFile:    "resp.shutdown.interface.json",
+		rollbackDelay: 200 * time.Millisecond,
+	}
+	cli, closeServer := newChangeTestClient(srv)
+	defer closeServer()
+
+	// the state of a change is available while it is rolled back.
+	tx, err := cli.ApplyChange(&ChangeRequest{
+		Commands:       []string{"interface e1/1", "shutdown"},
+		ConfirmTimeout: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	states := make(chan string)
+	go func() {
+		states <- tx.State()
+	}()
+	select {
+	case state := <-states:
+		if state != ChangeRollingBack {
+			t.Fatalf("unexpected state: %s", state)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatalf("state blocked by rollback")
+	}
+	if err := tx.Wait(); err == nil || tx.State() != ChangeRolledBack {
+		t.Fatalf("unexpected rollback: %s, %v", tx.State(), err)
+	}
+
+	// the default checkpoints of the changes started at once differ.
+	srv.lock.Lock()
+	srv.rollbackDelay = 0
+	srv.checkpoints = nil
+	srv.lock.Unlock()
+	for i := 0; i < 2; i++ {
+		if _, err := cli.ApplyChange(&ChangeRequest{Commands: []string{"interface e1/1", "shutdown"}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := len(srv.checkpoints); n != 2 || srv.checkpoints[0] == srv.checkpoints[1] {
+		t.Fatalf("unexpected checkpoints: %v", srv.checkpoints)
+	}
+
+	// the failure to delete the checkpoint is part of the error.
+	srv.lock.Lock()
+	srv.deleteFile = "resp.no.checkpoint.2.json"
+	srv.lock.Unlock()
+	failed := func(cli *Client) error { return fmt.Errorf("bgp peer 10.0.0.1 is down") }
+	tx, err = cli.ApplyChange(&ChangeRequest{
+		Commands: []string{"interface e1/1", "shutdown"},
+		Checks:   []ChangeCheck{failed},
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed deleting checkpoint") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.State() != ChangeRolledBack {
+		t.Fatalf("unexpected state: %s", tx.State())
+	}
+	if err := cli.DeleteCheckpoint("before change"); err == nil || !strings.HasPrefix(err.Error(), "invalid checkpoint name") {
+		t.Fatalf("expected error for invalid checkpoint name: %v", err)
+	}
+}
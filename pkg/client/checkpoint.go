@@ -0,0 +1,255 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The modes of a rollback to a checkpoint.
+const (
+	RollbackAtomic             = "atomic"
+	RollbackBestEffort         = "best-effort"
+	RollbackStopAtFirstFailure = "stop-at-first-failure"
+)
+
+const checkpointTimestampFormat = "Mon, 15:04:05 2 Jan 2006"
+
+var (
+	checkpointNameRegex  = regexp.MustCompile(`^\d+\) (\S+):$`)
+	rollbackFailureRegex = regexp.MustCompile(`(?i)error|fail`)
+)
+
+// Checkpoint is a configuration checkpoint. The information in the
+// structure is from the output of "show checkpoint summary" command.
+type Checkpoint struct {
+	Name        string    `json:"name" xml:"name"`
+	System      bool      `json:"system" xml:"system"`
+	CreatedBy   string    `json:"created_by" xml:"created_by"`
+	CreatedAt   time.Time `json:"created_at" xml:"created_at"`
+	Size        int64     `json:"size" xml:"size"`
+	Description string    `json:"description" xml:"description"`
+}
+
+// RollbackPatch is the configuration applied by a rollback to a
+// checkpoint. The information in the structure is from the output of
+// "show diff rollback-patch running-config checkpoint" command.
+type RollbackPatch struct {
+	Text string      `json:"text" xml:"text"`
+	Tree *ConfigTree `json:"tree" xml:"tree"`
+}
+
+// RollbackResult is the result of a rollback to a checkpoint. The
+// information in the structure is from the output of "rollback
+// running-config checkpoint" command.
+type RollbackResult struct {
+	Checkpoint string   `json:"checkpoint" xml:"checkpoint"`
+	Mode       string   `json:"mode" xml:"mode"`
+	Success    bool     `json:"success" xml:"success"`
+	Errors     []string `json:"errors" xml:"errors"`
+	Output     string   `json:"output" xml:"output"`
+}
+
+// NewCheckpointsFromString returns Checkpoint instances from an input
+// string.
+func NewCheckpointsFromString(s string) ([]*Checkpoint, error) {
+	return NewCheckpointsFromBytes([]byte(s))
+}
+
+// NewCheckpointsFromBytes returns Checkpoint instances from an input byte
+// array. The timestamps are interpreted as UTC.
+func NewCheckpointsFromBytes(s []byte) ([]*Checkpoint, error) {
+	body, err := newCLIOutputFromBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckpoints(body, time.UTC)
+}
+
+// parseCheckpoints parses the output of "show checkpoint summary", e.g.
+//
+//	User Checkpoint Summary
+//	--------------------------------------------------------------------------------
+//	1) before-change:
+//	Created by admin
+//	Created at Tue, 21:15:02 18 Dec 2018
+//	Size is 25,617 bytes
+//	Description: None
+func parseCheckpoints(s string, loc *time.Location) ([]*Checkpoint, error) {
+	checkpoints := []*Checkpoint{}
+	var checkpoint *Checkpoint
+	var system bool
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "User Checkpoint Summary":
+			system = false
+		case line == "System Checkpoint Summary":
+			system = true
+		case checkpointNameRegex.MatchString(line):
+			checkpoint = &Checkpoint{
+				Name:   checkpointNameRegex.FindStringSubmatch(line)[1],
+				System: system,
+			}
+			checkpoints = append(checkpoints, checkpoint)
+		case checkpoint == nil:
+		case strings.HasPrefix(line, "Created by "):
+			checkpoint.CreatedBy = strings.TrimPrefix(line, "Created by ")
+		case strings.HasPrefix(line, "Created at "):
+			ts, err := time.ParseInLocation(checkpointTimestampFormat, strings.TrimPrefix(line, "Created at "), loc)
+			if err != nil {
+				return nil, fmt.Errorf("malformed checkpoint timestamp: %s", line)
+			}
+			checkpoint.CreatedAt = ts
+		case strings.HasPrefix(line, "Size is "):
+			size := strings.TrimSuffix(strings.TrimPrefix(line, "Size is "), " bytes")
+			checkpoint.Size, _ = strconv.ParseInt(strings.Replace(size, ",", "", -1), 10, 64)
+		case strings.HasPrefix(line, "Description:"):
+			checkpoint.Description = strings.TrimSpace(strings.TrimPrefix(line, "Description:"))
+			if checkpoint.Description == "None" {
+				checkpoint.Description = ""
+			}
+		}
+	}
+	return checkpoints, nil
+}
+
+// NewRollbackPatchFromString returns RollbackPatch instance from an input
+// string.
+func NewRollbackPatchFromString(s string) (*RollbackPatch, error) {
+	return NewRollbackPatchFromBytes([]byte(s))
+}
+
+// NewRollbackPatchFromBytes returns RollbackPatch instance from an input
+// byte array.
+func NewRollbackPatchFromBytes(s []byte) (*RollbackPatch, error) {
+	body, err := newCLIOutputFromBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	return parseRollbackPatch(body)
+}
+
+// parseRollbackPatch parses the output of "show diff rollback-patch", e.g.
+//
+//	Collecting Running-Config
+//	#Generating Rollback Patch
+//	!!
+//	interface Ethernet1/1
+//	  no description server-02
+//	  description server-01
+//
+// The progress messages are not part of the patch.
+func parseRollbackPatch(s string) (*RollbackPatch, error) {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		text := strings.TrimSpace(line)
+		if strings.HasPrefix(text, "#") ||
+			strings.HasPrefix(text, "Collecting ") ||
+			strings.HasPrefix(text, "Rollback Patch is Empty") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	text := strings.Join(lines, "\n")
+	tree, err := parseConfigTree(text)
+	if err != nil {
+		return nil, err
+	}
+	return &RollbackPatch{
+		Text: strings.TrimSpace(text),
+		Tree: tree,
+	}, nil
+}
+
+// IsEmpty returns true when the running configuration is the same as the
+// checkpoint.
+func (p *RollbackPatch) IsEmpty() bool {
+	return len(p.Tree.Lines) == 0
+}
+
+// Commands returns the commands of the patch, with the sections of nested
+// commands entered before the commands.
+func (p *RollbackPatch) Commands() []string {
+	e := &configCommandEmitter{}
+	for _, line := range p.Tree.Lines {
+		e.emitTree(nil, line)
+	}
+	return e.commands
+}
+
+// NewRollbackResultFromString returns RollbackResult instance from an input
+// string.
+func NewRollbackResultFromString(s string) (*RollbackResult, error) {
+	return NewRollbackResultFromBytes([]byte(s))
+}
+
+// NewRollbackResultFromBytes returns RollbackResult instance from an input
+// byte array.
+func NewRollbackResultFromBytes(s []byte) (*RollbackResult, error) {
+	body, err := newCLIOutputFromBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	return parseRollbackResult(body), nil
+}
+
+// parseRollbackResult parses the output of "rollback running-config", e.g.
+//
+//	Collecting Running-Config
+//	#Generating Rollback Patch
+//	Executing Rollback Patch
+//	Syntax error while parsing 'interface Ethernet1/99'
+//	Generating Running-config for verification
+//	Generating Patch for verification
+//	Verification failed, Rolling back to previous configuration.
+//	Rollback failed.
+//
+// The notes, e.g. "Note: Applying config parallelly may fail Rollback
+// verification", are not errors.
+func parseRollbackResult(s string) *RollbackResult {
+	result := &RollbackResult{
+		Errors: []string{},
+		Output: strings.TrimSpace(s),
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Rollback completed successfully"):
+			result.Success = true
+		case strings.HasPrefix(line, "Note:"):
+		case rollbackFailureRegex.MatchString(line):
+			result.Errors = append(result.Errors, line)
+		}
+	}
+	if len(result.Errors) > 0 {
+		result.Success = false
+	}
+	return result
+}
+
+// validateCheckpointName returns an error for a checkpoint name the CLI
+// does not accept as a single argument, e.g. an empty name or a name with
+// spaces.
+func validateCheckpointName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t\r\n") {
+		return fmt.Errorf("invalid checkpoint name: %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseShowCheckpointSummaryOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.show.checkpoint.summary.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	checkpoints, err := NewCheckpointsFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if len(checkpoints) != 3 {
+		t.Fatalf("unexpected number of checkpoints: %d", len(checkpoints))
+	}
+	exp := &Checkpoint{
+		Name:        "nightly",
+		CreatedBy:   "automation",
+		CreatedAt:   time.Date(2018, time.December, 19, 2, 0, 11, 0, time.UTC),
+		Size:        25700,
+		Description: "nightly backup",
+	}
+	if !reflect.DeepEqual(exp, checkpoints[1]) {
+		t.Fatalf("unexpected checkpoint: %v", checkpoints[1])
+	}
+	if checkpoints[0].Description != "" || checkpoints[0].System {
+		t.Fatalf("unexpected checkpoint: %v", checkpoints[0])
+	}
+	if !checkpoints[2].System || checkpoints[2].CreatedAt.Hour() != 3 {
+		t.Fatalf("unexpected system checkpoint: %v", checkpoints[2])
+	}
+}
+
+func TestParseShowDiffRollbackPatchOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.show.diff.rollback-patch.checkpoint.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	patch, err := NewRollbackPatchFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if patch.IsEmpty() {
+		t.Fatalf("unexpected empty patch")
+	}
+	exp := []string{
+		"no vlan 30",
+		"interface Ethernet1/1",
+		"no description server-02",
+		"description server-01",
+		"spanning-tree port type edge",
+		"router bgp 65001",
+		"neighbor 10.0.0.1",
+		"remote-as 65000",
+	}
+	if commands := patch.Commands(); !reflect.DeepEqual(exp, commands) {
+		t.Fatalf("unexpected commands: %v", commands)
+	}
+
+	patch, err = NewRollbackPatchFromString(`{"ins_api":{"outputs":{"output":{"code":"200",
+		"body":"Collecting Running-Config\n#Generating Rollback Patch\nRollback Patch is Empty\n"}}}}`)
+	if err != nil {
+		t.Fatalf("failed parsing empty patch: %v", err)
+	}
+	if !patch.IsEmpty() {
+		t.Fatalf("expected empty patch: %s", patch.Text)
+	}
+}
+
+func TestParseRollbackOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.rollback.running-config.checkpoint.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	result, err := NewRollbackResultFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if !result.Success || len(result.Errors) != 0 {
+		t.Fatalf("unexpected rollback result: %v", result)
+	}
+
+	result, err = NewRollbackResultFromString(`{"ins_api":{"outputs":{"output":{"code":"200",
+		"body":"Executing Rollback Patch\nSyntax error while parsing 'interface Ethernet1/99'\nVerification failed, Rolling back to previous configuration.\nRollback failed.\n"}}}}`)
+	if err != nil {
+		t.Fatalf("failed parsing failed rollback: %v", err)
+	}
+	expErrors := []string{
+		"Syntax error while parsing 'interface Ethernet1/99'",
+		"Verification failed, Rolling back to previous configuration.",
+		"Rollback failed.",
+	}
+	if result.Success || !reflect.DeepEqual(expErrors, result.Errors) {
+		t.Fatalf("unexpected rollback result: %v", result)
+	}
+}
+
+func TestCheckpointNameValidation(t *testing.T) {
+	cli := NewClient()
+	for _, name := range []string{"", "before change", "before\nchange"} {
+		errs := []error{cli.CreateCheckpoint(name)}
+		_, err := cli.DiffCheckpoint(name)
+		errs = append(errs, err)
+		_, err = cli.Rollback(name, RollbackAtomic)
+		errs = append(errs, err)
+		for i, err := range errs {
+			if err == nil || !strings.HasPrefix(err.Error(), "invalid checkpoint name") {
+				t.Fatalf("test %d: expected error for checkpoint name %q, got: %v", i, name, err)
+			}
+		}
+	}
+}
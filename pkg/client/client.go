@@ -543,6 +543,955 @@ func (cli *Client) GetCDPNeighbors() (*CDPNeighborTable, error) {
 	return NewCDPNeighborTableFromBytes(resp)
 }
 
+// GetHSRP returns HSRP group information ("show hsrp detail").
+func (cli *Client) GetHSRP() ([]*HSRPGroup, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show hsrp detail"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewHSRPGroupsFromBytes(resp)
+}
+
+// GetVRRP returns VRRP group information ("show vrrp detail").
+func (cli *Client) GetVRRP() ([]*VRRPGroup, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show vrrp detail"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewVRRPGroupsFromBytes(resp)
+}
+
+// GetPIMNeighbors returns PIM neighbors in all VRFs
+// ("show ip pim neighbor vrf all").
+func (cli *Client) GetPIMNeighbors() ([]*PIMNeighbor, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show ip pim neighbor vrf all"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewPIMNeighborsFromBytes(resp)
+}
+
+// GetMRoutes returns multicast routing table ("show ip mroute [vrf <vrf>]").
+// vrf is optional, indicates showing the default or a specific VRF's table.
+func (cli *Client) GetMRoutes(vrf string) ([]*MRoute, error) {
+	var req []*JSONRPCRequest
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	if vrf != "" {
+		req = NewJSONRPCRequest([]string{"show ip mroute vrf " + vrf})
+	} else {
+		req = NewJSONRPCRequest([]string{"show ip mroute"})
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewMRoutesFromBytes(resp)
+}
+
+// GetIGMPSnoopingGroups returns IGMP snooping groups
+// ("show ip igmp snooping groups [vlan <vlan>]"). vlan is optional, zero
+// indicates showing the groups of all vlans.
+func (cli *Client) GetIGMPSnoopingGroups(vlan int) ([]*IGMPSnoopingGroup, error) {
+	var req []*JSONRPCRequest
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	if vlan > 0 {
+		req = NewJSONRPCRequest([]string{fmt.Sprintf("show ip igmp snooping groups vlan %d", vlan)})
+	} else {
+		req = NewJSONRPCRequest([]string{"show ip igmp snooping groups"})
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewIGMPSnoopingGroupsFromBytes(resp)
+}
+
+// GetBFDNeighbors returns BFD session information
+// ("show bfd neighbors detail").
+func (cli *Client) GetBFDNeighbors() ([]*BFDNeighbor, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show bfd neighbors detail"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewBFDNeighborsFromBytes(resp)
+}
+
+// GetAccessLists returns IPv4, IPv6 and MAC access lists with their
+// match counters ("show access-lists").
+func (cli *Client) GetAccessLists() ([]*AccessList, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show access-lists"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccessListsFromBytes(resp)
+}
+
+// GetObjectGroups returns ObjectGroup instances ("show object-group").
+func (cli *Client) GetObjectGroups() ([]*ObjectGroup, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show object-group")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewObjectGroupsFromBytes(resp)
+}
+
+// GetPolicyMapInterface returns PolicyMapInterface instance with QoS class
+// and policer statistics of an interface ("show policy-map interface <name>").
+func (cli *Client) GetPolicyMapInterface(intf string) (*PolicyMapInterface, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show policy-map interface " + intf)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewPolicyMapInterfaceFromBytes(resp)
+}
+
+// GetQueuing returns Queuing instance with per-queue statistics of an
+// interface ("show queuing interface <name>").
+func (cli *Client) GetQueuing(intf string) (*Queuing, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show queuing interface " + intf)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewQueuingFromBytes(resp)
+}
+
+// GetLogging returns the messages logged since a particular time
+// ("show logging logfile start-time <since>"). The timestamps of the
+// messages are interpreted in the location of since, therefore it should
+// match the time zone of the device. A zero since returns all messages.
+func (cli *Client) GetLogging(since time.Time) ([]*LogMessage, error) {
+	cmd := "show logging logfile"
+	if !since.IsZero() {
+		cmd += " start-time " + since.Format("2006 Jan 2 15:04:05")
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(cmd)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := newLogMessagesFromBytes(resp, since.Location())
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*LogMessage
+	for _, m := range messages {
+		if m.Timestamp.Before(since) {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered, nil
+}
+
+// GetLoggingLast returns the last n logged messages ("show logging last <n>").
+func (cli *Client) GetLoggingLast(n int) ([]*LogMessage, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid number of messages: %d", n)
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(fmt.Sprintf("show logging last %d", n))
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewLogMessagesFromBytes(resp)
+}
+
+// GetAccountingLog returns the accounting log entries recorded since a
+// particular time ("show accounting log start-time <since>"). The timestamps
+// of the entries are interpreted in the location of since. A zero since
+// returns all entries.
+func (cli *Client) GetAccountingLog(since time.Time) ([]*AccountingLogEntry, error) {
+	cmd := "show accounting log"
+	if !since.IsZero() {
+		cmd += " start-time " + since.Format("2006 Jan 2 15:04:05")
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(cmd)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := newAccountingLogFromBytes(resp, since.Location())
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*AccountingLogEntry
+	for _, e := range entries {
+		if e.Timestamp.Before(since) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered, nil
+}
+
+// GetUsers returns the active user sessions ("show users").
+func (cli *Client) GetUsers() ([]*UserSession, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show users"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewUserSessionsFromBytes(resp)
+}
+
+// GetNTPPeers returns NTP peers ("show ntp peer-status").
+func (cli *Client) GetNTPPeers() ([]*NTPPeer, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show ntp peer-status"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewNTPPeersFromBytes(resp)
+}
+
+// GetSNMPHosts returns SNMP notification receivers ("show snmp host").
+// The communities of the hosts are redacted.
+func (cli *Client) GetSNMPHosts() ([]*SNMPHost, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show snmp host"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewSNMPHostsFromBytes(resp)
+}
+
+// GetSNMPCommunities returns SNMP communities ("show snmp community").
+// The names of the communities are redacted.
+func (cli *Client) GetSNMPCommunities() ([]*SNMPCommunity, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show snmp community"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewSNMPCommunitiesFromBytes(resp)
+}
+
+// GetTACACSServers returns TACACS+ servers ("show tacacs-server").
+func (cli *Client) GetTACACSServers() (*TACACSServers, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show tacacs-server"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewTACACSServersFromBytes(resp)
+}
+
+// GetAAAAuthentication returns the authentication methods of the services
+// ("show aaa authentication").
+func (cli *Client) GetAAAAuthentication() ([]*AAAAuthentication, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show aaa authentication")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewAAAAuthenticationFromBytes(resp)
+}
+
+// GetHardwareCapacity returns the utilization of TCAM regions ("show
+// hardware access-list resource utilization") and forwarding tables ("show
+// system internal forwarding table utilization").
+func (cli *Client) GetHardwareCapacity() (*HardwareCapacity, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	capacity := &HardwareCapacity{}
+	for _, c := range []struct {
+		cmd   string
+		parse func([]byte) error
+	}{
+		{
+			cmd: "show hardware access-list resource utilization",
+			parse: func(b []byte) (err error) {
+				capacity.TCAMRegions, err = NewTCAMRegionsFromBytes(b)
+				return err
+			},
+		},
+		{
+			cmd: "show system internal forwarding table utilization",
+			parse: func(b []byte) (err error) {
+				capacity.ForwardingTables, err = NewForwardingTablesFromBytes(b)
+				return err
+			},
+		},
+	} {
+		req := NewInsAPICliShowASCIIRequest(c.cmd)
+		payload, err := json.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := cli.callAPI("json", url, payload)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.parse(resp); err != nil {
+			return nil, err
+		}
+	}
+	return capacity, nil
+}
+
+// GetFeatures returns the state of the features ("show feature").
+func (cli *Client) GetFeatures() ([]*Feature, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show feature"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewFeaturesFromBytes(resp)
+}
+
+// GetLicenseUsage returns the usage of the licenses ("show license usage").
+func (cli *Client) GetLicenseUsage() ([]*LicenseUsage, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewJSONRPCRequest([]string{"show license usage"})
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("jsonrpc", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewLicenseUsageFromBytes(resp)
+}
+
+// ListDir returns the content of a directory on a filesystem, e.g.
+// ListDir("bootflash", "scripts") ("dir bootflash:scripts"). An empty path
+// lists the root of the filesystem.
+func (cli *Client) ListDir(fs, path string) (*DirListing, error) {
+	fs = strings.TrimSuffix(fs, ":")
+	if fs == "" {
+		return nil, fmt.Errorf("empty filesystem")
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(fmt.Sprintf("dir %s:%s", fs, path))
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewDirListingFromBytes(resp)
+}
+
+// FileChecksum returns the md5 or sha256 checksum of a file, e.g.
+// FileChecksum("bootflash:nxos.bin", "sha256") ("show file
+// bootflash:nxos.bin sha256sum").
+func (cli *Client) FileChecksum(path, algorithm string) (string, error) {
+	switch algorithm {
+	case "md5", "sha256":
+	default:
+		return "", fmt.Errorf("unsupported checksum algorithm: %s", algorithm)
+	}
+	if path == "" {
+		return "", fmt.Errorf("empty path")
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(fmt.Sprintf("show file %s %ssum", path, algorithm))
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return "", err
+	}
+	return NewFileChecksumFromBytes(resp)
+}
+
+// GetBootVariables returns the boot variables of the supervisors ("show
+// boot").
+func (cli *Client) GetBootVariables() ([]*BootVariables, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show boot")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewBootVariablesFromBytes(resp)
+}
+
+// GetInstallImpact returns the impact of upgrading to an image, e.g.
+// GetInstallImpact("bootflash:nxos.9.2.2.bin") ("show install all impact
+// nxos bootflash:nxos.9.2.2.bin").
+func (cli *Client) GetInstallImpact(image string) (*InstallImpact, error) {
+	if image == "" {
+		return nil, fmt.Errorf("empty image")
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show install all impact nxos " + image)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewInstallImpactFromBytes(resp)
+}
+
+// GetErrDisabled returns the interfaces in err-disabled state ("show
+// interface status err-disabled") with their errdisable recovery ("show
+// errdisable recovery").
+func (cli *Client) GetErrDisabled() ([]*ErrDisabledInterface, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show interface status err-disabled")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := NewErrDisabledInterfacesFromBytes(resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(interfaces) == 0 {
+		return interfaces, nil
+	}
+	req = NewInsAPICliShowASCIIRequest("show errdisable recovery")
+	payload, err = json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	recovery, err := NewErrDisableRecoveryFromBytes(resp)
+	if err != nil {
+		return nil, err
+	}
+	for _, intf := range interfaces {
+		recovery.Apply(intf)
+	}
+	return interfaces, nil
+}
+
+// GetUDLDNeighbors returns UDLD neighbors ("show udld neighbors").
+func (cli *Client) GetUDLDNeighbors() ([]*UDLDNeighbor, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show udld neighbors")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewUDLDNeighborsFromBytes(resp)
+}
+
+// GetPortSecurity returns port security status of the interfaces ("show
+// port-security").
+func (cli *Client) GetPortSecurity() ([]*PortSecurity, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show port-security")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewPortSecurityFromBytes(resp)
+}
+
+// GetDHCPSnoopingBindings returns the DHCP snooping binding database
+// ("show ip dhcp snooping binding").
+func (cli *Client) GetDHCPSnoopingBindings() ([]*DHCPSnoopingBinding, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show ip dhcp snooping binding")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewDHCPSnoopingBindingsFromBytes(resp)
+}
+
+// GetDHCPRelayStatistics returns DHCP relay statistics ("show ip dhcp relay
+// statistics").
+func (cli *Client) GetDHCPRelayStatistics() (*DHCPRelayStatistics, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show ip dhcp relay statistics")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewDHCPRelayStatisticsFromBytes(resp)
+}
+
+// CreateCheckpoint creates a checkpoint of the running configuration,
+// e.g. CreateCheckpoint("before-change") ("checkpoint before-change").
+func (cli *Client) CreateCheckpoint(name string) error {
+	if err := validateCheckpointName(name); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("checkpoint " + name)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return err
+	}
+	body, err := newCLIOutputFromBytes(resp)
+	if err != nil {
+		return err
+	}
+	return cliOutputError(body)
+}
+
+// DeleteCheckpoint deletes a checkpoint ("no checkpoint").
+func (cli *Client) DeleteCheckpoint(name string) error {
+	if err := validateCheckpointName(name); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("no checkpoint " + name)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return err
+	}
+	body, err := newCLIOutputFromBytes(resp)
+	if err != nil {
+		return err
+	}
+	return cliOutputError(body)
+}
+
+// ListCheckpoints returns the user and the system checkpoints ("show
+// checkpoint summary").
+func (cli *Client) ListCheckpoints() ([]*Checkpoint, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show checkpoint summary")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewCheckpointsFromBytes(resp)
+}
+
+// DiffCheckpoint returns the configuration a rollback to a checkpoint
+// applies to the running configuration ("show diff rollback-patch
+// running-config checkpoint").
+func (cli *Client) DiffCheckpoint(name string) (*RollbackPatch, error) {
+	if err := validateCheckpointName(name); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("show diff rollback-patch running-config checkpoint " + name)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewRollbackPatchFromBytes(resp)
+}
+
+// Rollback reverts the running configuration to a checkpoint ("rollback
+// running-config checkpoint"). The mode is one of RollbackAtomic,
+// RollbackBestEffort or RollbackStopAtFirstFailure, and defaults to
+// RollbackAtomic. A failed rollback is reported in RollbackResult.
+func (cli *Client) Rollback(name, mode string) (*RollbackResult, error) {
+	if err := validateCheckpointName(name); err != nil {
+		return nil, err
+	}
+	switch mode {
+	case "":
+		mode = RollbackAtomic
+	case RollbackAtomic, RollbackBestEffort, RollbackStopAtFirstFailure:
+	default:
+		return nil, fmt.Errorf("unsupported rollback mode: %s", mode)
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(fmt.Sprintf("rollback running-config checkpoint %s %s", name, mode))
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewRollbackResultFromBytes(resp)
+	if err != nil {
+		return nil, err
+	}
+	result.Checkpoint = name
+	result.Mode = mode
+	return result, nil
+}
+
+// SaveConfig saves the running configuration to the startup
+// configuration ("copy running-config startup-config"). A failed copy is
+// reported in CopyResult.
+func (cli *Client) SaveConfig() (*CopyResult, error) {
+	return cli.CopyFile("running-config", "startup-config")
+}
+
+// CopyFile copies a file, e.g. CopyFile("bootflash:nxos.bin",
+// "tftp://192.168.1.5/backup/nxos.bin") ("copy"). The source and the
+// destination are either local files, e.g. "bootflash:nxos.bin", tftp
+// files in the default vrf, or configurations, e.g. "running-config".
+// NX-API cannot answer the prompts of a copy, therefore the scp, sftp and
+// ftp files, prompting for a password, are not supported, and a local
+// destination file must not exist. A failed copy is reported in
+// CopyResult.
+func (cli *Client) CopyFile(src, dst string) (*CopyResult, error) {
+	for _, uri := range []string{src, dst} {
+		if uri == "" || strings.ContainsAny(uri, " \t\r\n") {
+			return nil, fmt.Errorf("invalid file: %q", uri)
+		}
+		if i := strings.Index(uri, "://"); i >= 0 && uri[:i] != "tftp" {
+			return nil, fmt.Errorf("unsupported file transfer: %s", uri[:i])
+		}
+		if !strings.Contains(uri, ":") && uri != "running-config" && uri != "startup-config" {
+			return nil, fmt.Errorf("file without filesystem or scheme: %s", uri)
+		}
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(fmt.Sprintf("copy %s %s", src, dst))
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewCopyResultFromBytes(resp)
+	if err != nil {
+		return nil, err
+	}
+	result.Source = src
+	result.Destination = dst
+	return result, nil
+}
+
+// ConfigReplace replaces the running configuration with the configuration
+// in a file, e.g. ConfigReplace("bootflash:golden.cfg", 60) ("configure
+// replace bootflash:golden.cfg commit-timeout 60"). With a commit timeout,
+// between 30 and 3600 seconds, the replacement is rolled back unless
+// committed with ConfigReplaceCommit in time. A failed replacement is
+// reported in ConfigReplaceResult.
+func (cli *Client) ConfigReplace(file string, commitTimeout int) (*ConfigReplaceResult, error) {
+	if file == "" || strings.ContainsAny(file, " \t") {
+		return nil, fmt.Errorf("invalid file: %q", file)
+	}
+	cmd := "configure replace " + file
+	if commitTimeout != 0 {
+		if commitTimeout < 30 || commitTimeout > 3600 {
+			return nil, fmt.Errorf("commit timeout out of range 30-3600: %d", commitTimeout)
+		}
+		cmd += fmt.Sprintf(" commit-timeout %d", commitTimeout)
+	}
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest(cmd)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewConfigReplaceResultFromBytes(resp)
+	if err != nil {
+		return nil, err
+	}
+	result.File = file
+	result.CommitTimeout = commitTimeout
+	return result, nil
+}
+
+// ConfigReplaceCommit commits the replacement of the running
+// configuration started with a commit timeout ("configure replace
+// commit").
+func (cli *Client) ConfigReplaceCommit() error {
+	url := fmt.Sprintf("%s://%s:%d/ins", cli.protocol, cli.host, cli.port)
+	req := NewInsAPICliShowASCIIRequest("configure replace commit")
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resp, err := cli.callAPI("json", url, payload)
+	if err != nil {
+		return err
+	}
+	body, err := newCLIOutputFromBytes(resp)
+	if err != nil {
+		return err
+	}
+	return cliOutputError(body)
+}
+
+// ReconcileVlans creates, updates and, with the Prune option, deletes
+// VLANs to match their desired configuration, and returns the changes.
+// With the DryRun option, the changes are not applied.
+func (cli *Client) ReconcileVlans(desired []*VlanSpec, opts VlanReconcileOptions) (*VlanPlan, error) {
+	current, err := cli.GetVlans()
+	if err != nil {
+		return nil, err
+	}
+	plan, err := PlanVlans(current, desired, opts.Prune)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun || plan.IsEmpty() {
+		return plan, nil
+	}
+	cmds := plan.Commands()
+	resp, err := cli.Configure(cmds)
+	if err != nil {
+		return plan, err
+	}
+	if err := jsonRPCResponsesError(cmds, resp); err != nil {
+		return plan, err
+	}
+	plan.Applied = true
+	return plan, nil
+}
+
+// ReconcileInterfaces changes interfaces to match their desired
+// configuration, and returns the changes. Only the settings differing
+// from the desired configuration are changed, and the interfaces in the
+// desired state are not touched. With the DryRun option, the changes are
+// not applied.
+func (cli *Client) ReconcileInterfaces(specs []*InterfaceSpec, opts InterfaceReconcileOptions) ([]*InterfacePlan, error) {
+	var plans []*InterfacePlan
+	var cmds []string
+	for _, spec := range specs {
+		intf, err := cli.GetInterface(spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		conf, err := cli.GetInterfaceRunningConfiguration(spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		tree, err := conf.Tree()
+		if err != nil {
+			return nil, err
+		}
+		var section *ConfigLine
+		if sections := tree.Find("interface"); len(sections) > 0 {
+			section = sections[0]
+		}
+		plan, err := PlanInterface(intf, section, spec)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+		cmds = append(cmds, plan.Commands...)
+	}
+	if opts.DryRun || len(cmds) == 0 {
+		return plans, nil
+	}
+	resp, err := cli.Configure(cmds)
+	if err != nil {
+		return plans, err
+	}
+	if err := jsonRPCResponsesError(cmds, resp); err != nil {
+		return plans, err
+	}
+	for _, plan := range plans {
+		plan.Applied = !plan.IsEmpty()
+	}
+	return plans, nil
+}
+
+// DiffConfig returns the difference between the running configuration and
+// a partial configuration, e.g. a rendered ConfigTemplate. Only the top
+// level lines and the sections of the partial configuration are compared,
+// and the lines absent from the sections are removed only with prune.
+func (cli *Client) DiffConfig(conf *Configuration, prune bool) (*ConfigDiff, error) {
+	tree, err := conf.Tree()
+	if err != nil {
+		return nil, err
+	}
+	running, err := cli.GetRunningConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	runningTree, err := running.Tree()
+	if err != nil {
+		return nil, err
+	}
+	return DiffManagedConfigTrees(runningTree, tree, prune), nil
+}
+
+// PushConfig pushes a configuration, e.g. a rendered ConfigTemplate, with
+// Configure. With the Diff option, only the commands changing the running
+// configuration are pushed, and with the Prune option, the lines absent
+// from the sections of the configuration are removed. With the DryRun option, the commands are
+// returned, but not pushed.
+func (cli *Client) PushConfig(conf *Configuration, opts ConfigPushOptions) (*ConfigPushResult, error) {
+	tree, err := conf.Tree()
+	if err != nil {
+		return nil, err
+	}
+	result := &ConfigPushResult{}
+	if opts.Diff {
+		diff, err := cli.DiffConfig(conf, opts.Prune)
+		if err != nil {
+			return nil, err
+		}
+		result.Diff = diff
+		result.Commands = diff.Commands()
+	} else {
+		result.Commands = renderConfigLines(tree.Lines...)
+	}
+	if opts.DryRun || len(result.Commands) == 0 {
+		return result, nil
+	}
+	resp, err := cli.Configure(result.Commands)
+	if err != nil {
+		return result, err
+	}
+	if err := jsonRPCResponsesError(result.Commands, resp); err != nil {
+		return result, err
+	}
+	result.Applied = true
+	return result, nil
+}
+
 // Configure execute a batch of configuration commands
 func (cli *Client) Configure(cmds []string) ([]JSONRPCResponse, error) {
 	if len(cmds) == 0 {
@@ -35,17 +35,61 @@ func TestClient(t *testing.T) {
 		var fc []byte
 		dataDir := "../../assets/requests"
 		showCmdFileMap := map[string]string{
-			"show version":                       "resp.show.version.1.json",
-			"show vlan":                          "resp.show.vlans.2.json",
-			"show interface":                     "resp.show.interfaces.4.json",
-			"show system resources":              "resp.show.system.resources.1.json",
-			"show environment":                   "resp.show.environment.1.json",
-			"show running-config":                "resp.show.running.config.1.json",
-			"show ip bgp summary vrf all":        "resp.show.ip.bgp.summary.vrf.all.1.json",
-			"show interface transceiver details": "resp.show.interface.transceiver.details.1.json",
-			"show clock":                         "resp.show.clock.json",
-			"show mac address-table":             "resp.show.mac.address-table.1.json",
-			"show cdp neighbors":                 "resp.show.cdp.neighbors.json",
+			"show version":                                          "resp.show.version.1.json",
+			"show vlan":                                             "resp.show.vlans.2.json",
+			"show interface":                                        "resp.show.interfaces.4.json",
+			"show system resources":                                 "resp.show.system.resources.1.json",
+			"show environment":                                      "resp.show.environment.1.json",
+			"show running-config":                                   "resp.show.running.config.1.json",
+			"show ip bgp summary vrf all":                           "resp.show.ip.bgp.summary.vrf.all.1.json",
+			"show interface transceiver details":                    "resp.show.interface.transceiver.details.1.json",
+			"show clock":                                            "resp.show.clock.json",
+			"show mac address-table":                                "resp.show.mac.address-table.1.json",
+			"show cdp neighbors":                                    "resp.show.cdp.neighbors.json",
+			"show hsrp detail":                                      "resp.show.hsrp.detail.1.json",
+			"show vrrp detail":                                      "resp.show.vrrp.detail.1.json",
+			"show ip pim neighbor vrf all":                          "resp.show.ip.pim.neighbor.vrf.all.1.json",
+			"show ip mroute vrf default":                            "resp.show.ip.mroute.1.json",
+			"show ip igmp snooping groups vlan 500":                 "resp.show.ip.igmp.snooping.groups.1.json",
+			"show bfd neighbors detail":                             "resp.show.bfd.neighbors.detail.1.json",
+			"show access-lists":                                     "resp.show.access-lists.1.json",
+			"show object-group":                                     "resp.show.object-group.1.json",
+			"show policy-map interface ethernet1/1":                 "resp.show.policy-map.interface.1.json",
+			"show queuing interface ethernet1/1":                    "resp.show.queuing.interface.1.json",
+			"show logging logfile start-time 2018 Dec 18 21:00:00":  "resp.show.logging.logfile.1.json",
+			"show logging last 5":                                   "resp.show.logging.logfile.1.json",
+			"show accounting log start-time 2018 Dec 18 20:56:00":   "resp.show.accounting.log.1.json",
+			"show users":                                            "resp.show.users.1.json",
+			"show ntp peer-status":                                  "resp.show.ntp.peer-status.1.json",
+			"show snmp host":                                        "resp.show.snmp.host.1.json",
+			"show snmp community":                                   "resp.show.snmp.community.1.json",
+			"show tacacs-server":                                    "resp.show.tacacs-server.1.json",
+			"show aaa authentication":                               "resp.show.aaa.authentication.1.json",
+			"show hardware access-list resource utilization":        "resp.show.hardware.access-list.resource.utilization.1.json",
+			"show system internal forwarding table utilization":     "resp.show.system.internal.forwarding.table.utilization.1.json",
+			"show feature":                                          "resp.show.feature.1.json",
+			"show license usage":                                    "resp.show.license.usage.1.json",
+			"dir bootflash:":                                        "resp.dir.bootflash.1.json",
+			"show file bootflash:nxos.7.0.3.I7.5a.bin md5sum":       "resp.show.file.md5sum.1.json",
+			"show file bootflash:nxos.7.0.3.I7.5a.bin sha256sum":    "resp.show.file.sha256sum.1.json",
+			"show boot":                                             "resp.show.boot.1.json",
+			"show install all impact nxos bootflash:nxos.9.2.2.bin": "resp.show.install.all.impact.1.json",
+			"show interface status err-disabled":                    "resp.show.interface.status.err-disabled.1.json",
+			"show errdisable recovery":                              "resp.show.errdisable.recovery.1.json",
+			"show udld neighbors":                                   "resp.show.udld.neighbors.1.json",
+			"show port-security":                                    "resp.show.port-security.1.json",
+			"show ip dhcp snooping binding":                         "resp.show.ip.dhcp.snooping.binding.1.json",
+			"show ip dhcp relay statistics":                         "resp.show.ip.dhcp.relay.statistics.1.json",
+			"checkpoint before-change":                              "resp.checkpoint.1.json",
+			"show checkpoint summary":                               "resp.show.checkpoint.summary.1.json",
+			"show diff rollback-patch running-config checkpoint before-change":                   "resp.show.diff.rollback-patch.checkpoint.1.json",
+			"rollback running-config checkpoint before-change atomic":                            "resp.rollback.running-config.checkpoint.1.json",
+			"copy running-config startup-config":                                                 "resp.copy.running-config.startup-config.1.json",
+			"copy bootflash:nxos.7.0.3.I7.5a.bin tftp://192.168.1.5/backup/nxos.7.0.3.I7.5a.bin": "resp.copy.bootflash.2.json",
+			"configure replace bootflash:golden.cfg commit-timeout 60":                           "resp.configure.replace.1.json",
+			"configure replace commit":                                                           "resp.configure.replace.commit.1.json",
+			"show interface Ethernet1/1":                                                         "resp.show.interface.ethernet1.1.json",
+			"show running-config interface Ethernet1/1":                                          "resp.show.running.config.interface.1.json",
 		}
 		if req.Method != "POST" {
 			http.Error(w, "Bad Request, expecting POST", http.StatusBadRequest)
@@ -85,7 +129,7 @@ func TestClient(t *testing.T) {
 
 				fp = fmt.Sprintf("%s/%s", dataDir, respFileName)
 			} else {
-				// interface config commands
+				// interface and vlan config commands
 				var cmds []string
 				for i := range j {
 					cmds = append(cmds, j[i].Params.Command)
@@ -99,7 +143,7 @@ func TestClient(t *testing.T) {
 					}
 					fp = fmt.Sprintf("%s/%s", dataDir, "resp.vlan.json")
 				} else {
-					if !strings.HasPrefix(cmds[0], "interface") {
+					if !strings.HasPrefix(cmds[0], "interface") && !strings.HasPrefix(cmds[0], "vlan") {
 						http.Error(w, fmt.Sprintf("Wrong config command %s", cmds[0]), http.StatusBadRequest)
 						return
 					}
@@ -229,6 +273,307 @@ func TestClient(t *testing.T) {
 	}
 	t.Logf("client: MAC Addresses: %d", len(mac.Item))
 
+	hsrp, err := cli.GetHSRP()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: HSRP groups: %d", len(hsrp))
+
+	vrrp, err := cli.GetVRRP()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: VRRP groups: %d", len(vrrp))
+
+	pimNeighbors, err := cli.GetPIMNeighbors()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: PIM neighbors: %d", len(pimNeighbors))
+
+	mroutes, err := cli.GetMRoutes("default")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Multicast routes: %d", len(mroutes))
+
+	igmpGroups, err := cli.GetIGMPSnoopingGroups(500)
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: IGMP snooping groups: %d", len(igmpGroups))
+
+	bfdNeighbors, err := cli.GetBFDNeighbors()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: BFD neighbors: %d", len(bfdNeighbors))
+
+	acls, err := cli.GetAccessLists()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Access lists: %d", len(acls))
+
+	objectGroups, err := cli.GetObjectGroups()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Object groups: %d", len(objectGroups))
+
+	policyMap, err := cli.GetPolicyMapInterface("ethernet1/1")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Service policies: %d", len(policyMap.Policies))
+
+	queuing, err := cli.GetQueuing("ethernet1/1")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Queues: %d", len(queuing.Queues))
+
+	logMessages, err := cli.GetLogging(time.Date(2018, time.December, 18, 21, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if len(logMessages) != 4 {
+		t.Fatalf("client: unexpected number of log messages: %d", len(logMessages))
+	}
+	t.Logf("client: Log messages: %d", len(logMessages))
+
+	logMessages, err = cli.GetLoggingLast(5)
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Last log messages: %d", len(logMessages))
+
+	accountingLog, err := cli.GetAccountingLog(time.Date(2018, time.December, 18, 20, 56, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if len(accountingLog) != 3 {
+		t.Fatalf("client: unexpected number of accounting log entries: %d", len(accountingLog))
+	}
+	t.Logf("client: Accounting log entries: %d", len(accountingLog))
+
+	users, err := cli.GetUsers()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Users: %d", len(users))
+
+	ntpPeers, err := cli.GetNTPPeers()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: NTP peers: %d", len(ntpPeers))
+
+	snmpHosts, err := cli.GetSNMPHosts()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: SNMP hosts: %d", len(snmpHosts))
+
+	snmpCommunities, err := cli.GetSNMPCommunities()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: SNMP communities: %d", len(snmpCommunities))
+
+	tacacsServers, err := cli.GetTACACSServers()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: TACACS+ servers: %d", len(tacacsServers.Servers))
+
+	aaaAuthentication, err := cli.GetAAAAuthentication()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: AAA authentication services: %d", len(aaaAuthentication))
+
+	hardwareCapacity, err := cli.GetHardwareCapacity()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if len(hardwareCapacity.TCAMRegions) == 0 || len(hardwareCapacity.ForwardingTables) == 0 {
+		t.Fatalf("client: unexpected hardware capacity: %v", hardwareCapacity)
+	}
+	t.Logf("client: TCAM regions: %d, forwarding tables: %d",
+		len(hardwareCapacity.TCAMRegions), len(hardwareCapacity.ForwardingTables))
+
+	features, err := cli.GetFeatures()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if !FeatureEnabled(features, "bgp") {
+		t.Fatalf("client: feature bgp is not enabled")
+	}
+	t.Logf("client: Features: %d", len(features))
+
+	licenses, err := cli.GetLicenseUsage()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Licenses: %d", len(licenses))
+
+	dir, err := cli.ListDir("bootflash:", "")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Directory entries: %d, free: %d bytes", len(dir.Entries), dir.Free)
+
+	for _, algorithm := range []string{"md5", "sha256"} {
+		checksum, err := cli.FileChecksum("bootflash:nxos.7.0.3.I7.5a.bin", algorithm)
+		if err != nil {
+			t.Fatalf("client: %s", err)
+		}
+		t.Logf("client: File %s checksum: %s", algorithm, checksum)
+	}
+	if _, err := cli.FileChecksum("bootflash:nxos.7.0.3.I7.5a.bin", "crc32"); err == nil {
+		t.Fatalf("client: expected unsupported checksum algorithm error")
+	}
+
+	bootVariables, err := cli.GetBootVariables()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Supervisors: %d", len(bootVariables))
+
+	installImpact, err := cli.GetInstallImpact("bootflash:nxos.9.2.2.bin")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Install impact disruptive: %t", installImpact.IsDisruptive())
+
+	errDisabled, err := cli.GetErrDisabled()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if len(errDisabled) != 3 || !errDisabled[0].RecoveryEnabled {
+		t.Fatalf("client: unexpected err-disabled interfaces: %v", errDisabled)
+	}
+	t.Logf("client: Err-disabled interfaces: %d", len(errDisabled))
+
+	udldNeighbors, err := cli.GetUDLDNeighbors()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: UDLD neighbors: %d", len(udldNeighbors))
+
+	portSecurity, err := cli.GetPortSecurity()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Port security interfaces: %d", len(portSecurity))
+
+	dhcpBindings, err := cli.GetDHCPSnoopingBindings()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: DHCP snooping bindings: %d", len(dhcpBindings))
+
+	dhcpRelayStats, err := cli.GetDHCPRelayStatistics()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: DHCP relay messages received: %d", dhcpRelayStats.Total.Received)
+
+	if err := cli.CreateCheckpoint("before-change"); err != nil {
+		t.Fatalf("client: %s", err)
+	}
+
+	checkpoints, err := cli.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Checkpoints: %d", len(checkpoints))
+
+	patch, err := cli.DiffCheckpoint("before-change")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	t.Logf("client: Rollback patch commands: %d", len(patch.Commands()))
+
+	rollback, err := cli.Rollback("before-change", "")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if !rollback.Success {
+		t.Fatalf("client: rollback failed: %v", rollback.Errors)
+	}
+	t.Logf("client: Rollback to %s (%s) succeeded", rollback.Checkpoint, rollback.Mode)
+
+	saved, err := cli.SaveConfig()
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if !saved.Success {
+		t.Fatalf("client: saving configuration failed: %v", saved.Errors)
+	}
+
+	if _, err := cli.CopyFile("bootflash:nxos.7.0.3.I7.5a.bin", "scp://admin@192.168.1.5/backup/nxos.7.0.3.I7.5a.bin"); err == nil {
+		t.Fatalf("client: expected error for scp copy")
+	}
+	copied, err := cli.CopyFile("bootflash:nxos.7.0.3.I7.5a.bin", "tftp://192.168.1.5/backup/nxos.7.0.3.I7.5a.bin")
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if !copied.Success {
+		t.Fatalf("client: copy failed: %v", copied.Errors)
+	}
+	t.Logf("client: Copy of %s succeeded: %t, progress: %d%%", copied.Source, copied.Success, copied.Progress)
+
+	if _, err := cli.ConfigReplace("bootflash:golden.cfg", 10); err == nil {
+		t.Fatalf("client: expected error for commit timeout out of range")
+	}
+	replaced, err := cli.ConfigReplace("bootflash:golden.cfg", 60)
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if !replaced.Success || !replaced.CommitRequired {
+		t.Fatalf("client: unexpected configure replace result: %v", replaced)
+	}
+	if err := cli.ConfigReplaceCommit(); err != nil {
+		t.Fatalf("client: %s", err)
+	}
+
+	desiredVlans := []*VlanSpec{{ID: 10, Name: "SERVERS"}, {ID: 2345, Name: "VLAN2345"}}
+	vlanPlan, err := cli.ReconcileVlans(desiredVlans, VlanReconcileOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if vlanPlan.Applied || len(vlanPlan.Changes) != 1 {
+		t.Fatalf("client: unexpected vlan plan: %v", vlanPlan.Commands())
+	}
+	vlanPlan, err = cli.ReconcileVlans(desiredVlans, VlanReconcileOptions{})
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if !vlanPlan.Applied {
+		t.Fatalf("client: vlan plan not applied: %v", vlanPlan.Commands())
+	}
+
+	desiredInterfaces := []*InterfaceSpec{
+		{Name: "Ethernet1/1", Description: "connected to sw03", SwitchportMode: "trunk", TrunkAllowedVlans: "10,20"},
+	}
+	interfacePlans, err := cli.ReconcileInterfaces(desiredInterfaces, InterfaceReconcileOptions{})
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if len(interfacePlans) != 1 || !interfacePlans[0].Applied || len(interfacePlans[0].Changes) != 1 {
+		t.Fatalf("client: unexpected interface plans: %v", interfacePlans[0])
+	}
+
+	pushResult, err := cli.PushConfig(&Configuration{Text: "hostname ny-sw02\n"}, ConfigPushOptions{Diff: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("client: %s", err)
+	}
+	if pushResult.Applied || len(pushResult.Commands) != 1 || pushResult.Commands[0] != "hostname ny-sw02" {
+		t.Fatalf("client: unexpected push result: %v", pushResult.Commands)
+	}
+
 	output, err := cli.GetGeneric("show clock")
 	if err != nil {
 		t.Fatalf("client: %s", err)
@@ -0,0 +1,532 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	bgpASNRegex     = regexp.MustCompile(`^(\d+)(?:\.(\d+))?$`)
+	configNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,63}$`)
+)
+
+// ConfigBuilder is a configuration construct, e.g. a static route,
+// rendered into the commands for Configure.
+type ConfigBuilder interface {
+	Commands() ([]string, error)
+}
+
+// BuildConfig returns the commands of configuration constructs in order.
+func BuildConfig(builders ...ConfigBuilder) ([]string, error) {
+	var cmds []string
+	for _, b := range builders {
+		c, err := b.Commands()
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, c...)
+	}
+	return cmds, nil
+}
+
+// renderConfigLines returns the commands of configuration lines, with the
+// sections entered again after their nested sections.
+func renderConfigLines(lines ...*ConfigLine) []string {
+	e := &configCommandEmitter{}
+	for _, line := range lines {
+		e.emitTree(nil, line)
+	}
+	return e.commands
+}
+
+// Commands returns the commands configuring an interface.
+func (spec *InterfaceSpec) Commands() ([]string, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	name, _ := NormalizeInterfaceName(spec.Name)
+	return renderConfigLines(spec.config(name)), nil
+}
+
+func (spec *InterfaceSpec) config(name string) *ConfigLine {
+	line := &ConfigLine{Text: "interface " + name}
+	switch spec.SwitchportMode {
+	case "routed":
+		line.Add("no switchport")
+	case "access", "trunk":
+		line.Add("switchport")
+		line.Add("switchport mode " + spec.SwitchportMode)
+	}
+	if spec.AccessVlan != 0 {
+		line.Add(fmt.Sprintf("switchport access vlan %d", spec.AccessVlan))
+	}
+	if spec.TrunkAllowedVlans != "" {
+		vlans, _ := parseVlanRange(spec.TrunkAllowedVlans)
+		line.Add("switchport trunk allowed vlan " + formatVlanRange(vlans))
+	}
+	if spec.NativeVlan != 0 {
+		line.Add(fmt.Sprintf("switchport trunk native vlan %d", spec.NativeVlan))
+	}
+	if spec.MTU != 0 {
+		line.Add(fmt.Sprintf("mtu %d", spec.MTU))
+	}
+	if spec.Speed != "" {
+		line.Add("speed " + spec.Speed)
+	}
+	if spec.Description != "" {
+		line.Add("description " + spec.Description)
+	}
+	for i, addr := range spec.Addresses {
+		if i == 0 {
+			line.Add("ip address " + addr)
+		} else {
+			line.Add("ip address " + addr + " secondary")
+		}
+	}
+	if spec.ChannelGroup != 0 {
+		cmd := fmt.Sprintf("channel-group %d", spec.ChannelGroup)
+		if spec.ChannelGroupMode != "" {
+			cmd += " mode " + spec.ChannelGroupMode
+		}
+		line.Add(cmd)
+	}
+	switch spec.AdminState {
+	case "up":
+		line.Add("no shutdown")
+	case "down":
+		line.Add("shutdown")
+	}
+	return line
+}
+
+// Commands returns the commands configuring a VLAN.
+func (spec *VlanSpec) Commands() ([]string, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	line := &ConfigLine{Text: fmt.Sprintf("vlan %d", spec.ID)}
+	if spec.Name != "" {
+		line.Add("name " + spec.Name)
+	}
+	if spec.State != "" {
+		line.Add("state " + spec.State)
+	}
+	if spec.Mode != "" {
+		line.Add("mode " + spec.Mode)
+	}
+	return renderConfigLines(line), nil
+}
+
+// SVIConfig is the configuration of a VLAN interface. The first address is
+// the primary one, and the others are secondary.
+type SVIConfig struct {
+	Vlan        int      `json:"vlan" xml:"vlan"`
+	Description string   `json:"description" xml:"description"`
+	VRF         string   `json:"vrf" xml:"vrf"`
+	Addresses   []string `json:"addresses" xml:"addresses"`
+	MTU         int      `json:"mtu" xml:"mtu"`
+	Shutdown    bool     `json:"shutdown" xml:"shutdown"`
+}
+
+// Commands returns the commands configuring a VLAN interface. The VRF is
+// set before the addresses, because changing the VRF removes them.
+func (c *SVIConfig) Commands() ([]string, error) {
+	if c.Vlan < 1 || c.Vlan > 4094 {
+		return nil, fmt.Errorf("invalid vlan: %d", c.Vlan)
+	}
+	if c.VRF != "" && !configNameRegex.MatchString(c.VRF) {
+		return nil, fmt.Errorf("invalid vrf name: %q", c.VRF)
+	}
+	spec := &InterfaceSpec{
+		Name:        fmt.Sprintf("Vlan%d", c.Vlan),
+		Description: c.Description,
+		MTU:         c.MTU,
+		Addresses:   c.Addresses,
+		AdminState:  "up",
+	}
+	if c.Shutdown {
+		spec.AdminState = "down"
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	line := spec.config(spec.Name)
+	if c.VRF != "" {
+		line.Children = append([]*ConfigLine{{Text: "vrf member " + c.VRF, parent: line}}, line.Children...)
+	}
+	return renderConfigLines(line), nil
+}
+
+// PortChannelConfig is the configuration of a port-channel and its member
+// interfaces. The Mode of the members defaults to "active".
+type PortChannelConfig struct {
+	ID                int      `json:"id" xml:"id"`
+	Description       string   `json:"description" xml:"description"`
+	Members           []string `json:"members" xml:"members"`
+	Mode              string   `json:"mode" xml:"mode"`
+	SwitchportMode    string   `json:"switchport_mode" xml:"switchport_mode"`
+	AccessVlan        int      `json:"access_vlan" xml:"access_vlan"`
+	TrunkAllowedVlans string   `json:"trunk_allowed_vlans" xml:"trunk_allowed_vlans"`
+	NativeVlan        int      `json:"native_vlan" xml:"native_vlan"`
+	MTU               int      `json:"mtu" xml:"mtu"`
+}
+
+// Commands returns the commands configuring a port-channel, followed by
+// the commands adding the members to it.
+func (c *PortChannelConfig) Commands() ([]string, error) {
+	mode := c.Mode
+	if mode == "" {
+		mode = "active"
+	}
+	spec := &InterfaceSpec{
+		Name:              fmt.Sprintf("port-channel%d", c.ID),
+		Description:       c.Description,
+		SwitchportMode:    c.SwitchportMode,
+		AccessVlan:        c.AccessVlan,
+		TrunkAllowedVlans: c.TrunkAllowedVlans,
+		NativeVlan:        c.NativeVlan,
+		MTU:               c.MTU,
+		AdminState:        "up",
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	lines := []*ConfigLine{spec.config(spec.Name)}
+	for _, member := range c.Members {
+		name, err := NormalizeInterfaceName(member)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(name, "Ethernet") {
+			return nil, fmt.Errorf("port-channel %d: invalid member: %s", c.ID, name)
+		}
+		memberSpec := &InterfaceSpec{
+			Name:             name,
+			ChannelGroup:     c.ID,
+			ChannelGroupMode: mode,
+			AdminState:       "up",
+		}
+		if err := memberSpec.validate(); err != nil {
+			return nil, err
+		}
+		lines = append(lines, memberSpec.config(name))
+	}
+	return renderConfigLines(lines...), nil
+}
+
+// StaticRoute is a static route. Either the NextHop or the Interface, or
+// both, are required. The Distance of 0 is the default one.
+type StaticRoute struct {
+	Prefix    string `json:"prefix" xml:"prefix"`
+	NextHop   string `json:"next_hop" xml:"next_hop"`
+	Interface string `json:"interface" xml:"interface"`
+	VRF       string `json:"vrf" xml:"vrf"`
+	Name      string `json:"name" xml:"name"`
+	Tag       int    `json:"tag" xml:"tag"`
+	Distance  int    `json:"distance" xml:"distance"`
+}
+
+// Commands returns the commands configuring a static route, e.g.
+// "ip route 10.0.0.0/8 192.168.1.1 name core tag 100 250".
+func (r *StaticRoute) Commands() ([]string, error) {
+	prefix, err := parseConfigPrefix(r.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	keyword := "ip"
+	if prefix.IP.To4() == nil {
+		keyword = "ipv6"
+	}
+	fields := []string{keyword, "route", prefix.String()}
+	if r.NextHop == "" && r.Interface == "" {
+		return nil, fmt.Errorf("static route %s: no next hop", r.Prefix)
+	}
+	if r.Interface != "" {
+		name, err := NormalizeInterfaceName(r.Interface)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, name)
+	}
+	if r.NextHop != "" {
+		ip := net.ParseIP(r.NextHop)
+		if ip == nil || (ip.To4() == nil) != (keyword == "ipv6") {
+			return nil, fmt.Errorf("static route %s: invalid next hop: %s", r.Prefix, r.NextHop)
+		}
+		fields = append(fields, ip.String())
+	}
+	if r.Name != "" {
+		if !configNameRegex.MatchString(r.Name) {
+			return nil, fmt.Errorf("static route %s: invalid name: %q", r.Prefix, r.Name)
+		}
+		fields = append(fields, "name", r.Name)
+	}
+	if r.Tag != 0 {
+		if r.Tag < 0 {
+			return nil, fmt.Errorf("static route %s: invalid tag: %d", r.Prefix, r.Tag)
+		}
+		fields = append(fields, "tag", strconv.Itoa(r.Tag))
+	}
+	if r.Distance != 0 {
+		if r.Distance < 1 || r.Distance > 255 {
+			return nil, fmt.Errorf("static route %s: invalid distance: %d", r.Prefix, r.Distance)
+		}
+		fields = append(fields, strconv.Itoa(r.Distance))
+	}
+	line := &ConfigLine{Text: strings.Join(fields, " ")}
+	if r.VRF != "" {
+		if !configNameRegex.MatchString(r.VRF) {
+			return nil, fmt.Errorf("static route %s: invalid vrf name: %q", r.Prefix, r.VRF)
+		}
+		vrf := &ConfigLine{Text: "vrf context " + r.VRF}
+		vrf.Add(line.Text)
+		line = vrf
+	}
+	return renderConfigLines(line), nil
+}
+
+// PrefixListEntry is an entry of PrefixList. The GE and LE of 0 are not
+// set.
+type PrefixListEntry struct {
+	Seq    int    `json:"seq" xml:"seq"`
+	Action string `json:"action" xml:"action"`
+	Prefix string `json:"prefix" xml:"prefix"`
+	GE     int    `json:"ge" xml:"ge"`
+	LE     int    `json:"le" xml:"le"`
+}
+
+// PrefixList is an IPv4 or IPv6 prefix list.
+type PrefixList struct {
+	Name    string             `json:"name" xml:"name"`
+	Entries []*PrefixListEntry `json:"entries" xml:"entries"`
+}
+
+// Commands returns the commands configuring a prefix list, e.g.
+// "ip prefix-list LOOPBACKS seq 5 permit 10.255.0.0/16 le 32".
+func (p *PrefixList) Commands() ([]string, error) {
+	if !configNameRegex.MatchString(p.Name) {
+		return nil, fmt.Errorf("invalid prefix list name: %q", p.Name)
+	}
+	if len(p.Entries) == 0 {
+		return nil, fmt.Errorf("prefix list %s: no entries", p.Name)
+	}
+	var lines []*ConfigLine
+	seqs := make(map[int]bool)
+	for _, entry := range p.Entries {
+		if entry.Seq < 1 || seqs[entry.Seq] {
+			return nil, fmt.Errorf("prefix list %s: invalid sequence number: %d", p.Name, entry.Seq)
+		}
+		seqs[entry.Seq] = true
+		if entry.Action != "permit" && entry.Action != "deny" {
+			return nil, fmt.Errorf("prefix list %s: invalid action: %s", p.Name, entry.Action)
+		}
+		prefix, err := parseConfigPrefix(entry.Prefix)
+		if err != nil {
+			return nil, fmt.Errorf("prefix list %s: %s", p.Name, err)
+		}
+		keyword := "ip"
+		if prefix.IP.To4() == nil {
+			keyword = "ipv6"
+		}
+		length, bits := prefix.Mask.Size()
+		// the lengths are: length < ge <= le <= bits.
+		if entry.GE != 0 && (entry.GE <= length || entry.GE > bits) ||
+			entry.LE != 0 && (entry.LE <= length || entry.LE > bits || entry.LE < entry.GE) {
+			return nil, fmt.Errorf("prefix list %s: invalid prefix length range of %s", p.Name, prefix)
+		}
+		text := fmt.Sprintf("%s prefix-list %s seq %d %s %s", keyword, p.Name, entry.Seq, entry.Action, prefix)
+		if entry.GE != 0 {
+			text += fmt.Sprintf(" ge %d", entry.GE)
+		}
+		if entry.LE != 0 {
+			text += fmt.Sprintf(" le %d", entry.LE)
+		}
+		lines = append(lines, &ConfigLine{Text: text})
+	}
+	return renderConfigLines(lines...), nil
+}
+
+// RouteMapEntry is an entry of RouteMap, with its match and set clauses,
+// e.g. "ip address prefix-list LOOPBACKS" and "local-preference 200".
+type RouteMapEntry struct {
+	Seq    int      `json:"seq" xml:"seq"`
+	Action string   `json:"action" xml:"action"`
+	Match  []string `json:"match" xml:"match"`
+	Set    []string `json:"set" xml:"set"`
+}
+
+// RouteMap is a route map.
+type RouteMap struct {
+	Name    string           `json:"name" xml:"name"`
+	Entries []*RouteMapEntry `json:"entries" xml:"entries"`
+}
+
+// Commands returns the commands configuring a route map.
+func (m *RouteMap) Commands() ([]string, error) {
+	if !configNameRegex.MatchString(m.Name) {
+		return nil, fmt.Errorf("invalid route map name: %q", m.Name)
+	}
+	if len(m.Entries) == 0 {
+		return nil, fmt.Errorf("route map %s: no entries", m.Name)
+	}
+	var lines []*ConfigLine
+	seqs := make(map[int]bool)
+	for _, entry := range m.Entries {
+		if entry.Seq < 0 || entry.Seq > 65535 || seqs[entry.Seq] {
+			return nil, fmt.Errorf("route map %s: invalid sequence number: %d", m.Name, entry.Seq)
+		}
+		seqs[entry.Seq] = true
+		if entry.Action != "permit" && entry.Action != "deny" {
+			return nil, fmt.Errorf("route map %s: invalid action: %s", m.Name, entry.Action)
+		}
+		line := &ConfigLine{Text: fmt.Sprintf("route-map %s %s %d", m.Name, entry.Action, entry.Seq)}
+		for _, clause := range []struct {
+			keyword string
+			items   []string
+		}{{"match", entry.Match}, {"set", entry.Set}} {
+			for _, s := range clause.items {
+				s = strings.TrimSpace(s)
+				if s == "" || hasControlChars(s) {
+					return nil, fmt.Errorf("route map %s: invalid %s clause: %q", m.Name, clause.keyword, s)
+				}
+				line.Add(clause.keyword + " " + s)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return renderConfigLines(lines...), nil
+}
+
+// BGPNeighbor is a neighbor of a BGP instance. The neighbor is in the
+// default VRF, unless VRF is set, and its AddressFamilies default to
+// "ipv4 unicast" for an IPv4 neighbor and "ipv6 unicast" for an IPv6 one.
+type BGPNeighbor struct {
+	ASN             string   `json:"asn" xml:"asn"`
+	VRF             string   `json:"vrf" xml:"vrf"`
+	Address         string   `json:"address" xml:"address"`
+	RemoteAS        string   `json:"remote_as" xml:"remote_as"`
+	Description     string   `json:"description" xml:"description"`
+	UpdateSource    string   `json:"update_source" xml:"update_source"`
+	AddressFamilies []string `json:"address_families" xml:"address_families"`
+	RouteMapIn      string   `json:"route_map_in" xml:"route_map_in"`
+	RouteMapOut     string   `json:"route_map_out" xml:"route_map_out"`
+	SendCommunity   bool     `json:"send_community" xml:"send_community"`
+	Shutdown        bool     `json:"shutdown" xml:"shutdown"`
+}
+
+// Commands returns the commands configuring a BGP neighbor.
+func (n *BGPNeighbor) Commands() ([]string, error) {
+	for _, asn := range []string{n.ASN, n.RemoteAS} {
+		if !isBGPASN(asn) {
+			return nil, fmt.Errorf("invalid bgp as number: %q", asn)
+		}
+	}
+	ip := net.ParseIP(n.Address)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid bgp neighbor address: %q", n.Address)
+	}
+	families := n.AddressFamilies
+	if len(families) == 0 {
+		families = []string{"ipv4 unicast"}
+		if ip.To4() == nil {
+			families = []string{"ipv6 unicast"}
+		}
+	}
+	router := &ConfigLine{Text: "router bgp " + n.ASN}
+	parent := router
+	if n.VRF != "" {
+		if !configNameRegex.MatchString(n.VRF) {
+			return nil, fmt.Errorf("bgp neighbor %s: invalid vrf name: %q", n.Address, n.VRF)
+		}
+		parent = router.Add("vrf " + n.VRF)
+	}
+	neighbor := parent.Add("neighbor " + ip.String())
+	neighbor.Add("remote-as " + n.RemoteAS)
+	if n.Description != "" {
+		if len(n.Description) > 80 || hasControlChars(n.Description) {
+			return nil, fmt.Errorf("bgp neighbor %s: invalid description: %q", n.Address, n.Description)
+		}
+		neighbor.Add("description " + n.Description)
+	}
+	if n.UpdateSource != "" {
+		name, err := NormalizeInterfaceName(n.UpdateSource)
+		if err != nil {
+			return nil, err
+		}
+		neighbor.Add("update-source " + name)
+	}
+	if n.Shutdown {
+		neighbor.Add("shutdown")
+	}
+	for _, family := range families {
+		switch family {
+		case "ipv4 unicast", "ipv6 unicast", "ipv4 multicast", "ipv6 multicast", "l2vpn evpn":
+		default:
+			return nil, fmt.Errorf("bgp neighbor %s: invalid address family: %q", n.Address, family)
+		}
+		af := neighbor.Add("address-family " + family)
+		if n.SendCommunity {
+			af.Add("send-community")
+			af.Add("send-community extended")
+		}
+		for _, rm := range []struct{ name, direction string }{{n.RouteMapIn, "in"}, {n.RouteMapOut, "out"}} {
+			if rm.name == "" {
+				continue
+			}
+			if !configNameRegex.MatchString(rm.name) {
+				return nil, fmt.Errorf("bgp neighbor %s: invalid route map name: %q", n.Address, rm.name)
+			}
+			af.Add(fmt.Sprintf("route-map %s %s", rm.name, rm.direction))
+		}
+	}
+	return renderConfigLines(router), nil
+}
+
+// isBGPASN returns true for an AS number in asplain, e.g. "65001", or
+// asdot, e.g. "1.10", notation.
+func isBGPASN(s string) bool {
+	m := bgpASNRegex.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	if m[2] == "" {
+		asn, err := strconv.ParseUint(m[1], 10, 32)
+		return err == nil && asn > 0
+	}
+	high, err := strconv.ParseUint(m[1], 10, 16)
+	if err != nil {
+		return false
+	}
+	low, err := strconv.ParseUint(m[2], 10, 16)
+	return err == nil && high+low > 0
+}
+
+// parseConfigPrefix parses an IP prefix, and returns an error when the
+// address has host bits set, e.g. "10.0.0.1/8".
+func parseConfigPrefix(s string) (*net.IPNet, error) {
+	ip, prefix, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ip prefix: %q", s)
+	}
+	if !ip.Equal(prefix.IP) {
+		return nil, fmt.Errorf("invalid ip prefix: %q, host bits set", s)
+	}
+	return prefix, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildConfig(t *testing.T) {
+	cmds, err := BuildConfig(
+		&VlanSpec{ID: 10, Name: "SERVERS"},
+		&InterfaceSpec{Name: "e1/1", Description: "server-01", SwitchportMode: "access", AccessVlan: 10, AdminState: "up"},
+		&SVIConfig{Vlan: 10, VRF: "tenant-1", Addresses: []string{"10.10.0.1/24", "10.10.1.1/24"}},
+		&PortChannelConfig{ID: 20, SwitchportMode: "trunk", TrunkAllowedVlans: "10,11,12,20", Members: []string{"e1/49", "e1/50"}},
+		&StaticRoute{Prefix: "0.0.0.0/0", NextHop: "192.168.1.1", VRF: "management"},
+		&StaticRoute{Prefix: "10.0.0.0/8", Interface: "Vlan10", NextHop: "10.10.0.254", Name: "core", Tag: 100, Distance: 250},
+		&PrefixList{Name: "LOOPBACKS", Entries: []*PrefixListEntry{
+			{Seq: 5, Action: "permit", Prefix: "10.255.0.0/16", LE: 32},
+			{Seq: 10, Action: "deny", Prefix: "0.0.0.0/0", LE: 32},
+		}},
+		&RouteMap{Name: "EXPORT", Entries: []*RouteMapEntry{
+			{Seq: 10, Action: "permit", Match: []string{"ip address prefix-list LOOPBACKS"}, Set: []string{"local-preference 200"}},
+			{Seq: 20, Action: "deny"},
+		}},
+		&BGPNeighbor{
+			ASN: "65001", Address: "10.0.0.2", RemoteAS: "65002", Description: "spine-1",
+			UpdateSource: "lo0", RouteMapOut: "EXPORT", SendCommunity: true,
+			AddressFamilies: []string{"ipv4 unicast", "l2vpn evpn"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("failed building configuration: %v", err)
+	}
+	exp := []string{
+		"vlan 10",
+		"name SERVERS",
+		"interface Ethernet1/1",
+		"switchport",
+		"switchport mode access",
+		"switchport access vlan 10",
+		"description server-01",
+		"no shutdown",
+		"interface Vlan10",
+		"vrf member tenant-1",
+		"ip address 10.10.0.1/24",
+		"ip address 10.10.1.1/24 secondary",
+		"no shutdown",
+		"interface port-channel20",
+		"switchport",
+		"switchport mode trunk",
+		"switchport trunk allowed vlan 10-12,20",
+		"no shutdown",
+		"interface Ethernet1/49",
+		"channel-group 20 mode active",
+		"no shutdown",
+		"interface Ethernet1/50",
+		"channel-group 20 mode active",
+		"no shutdown",
+		"vrf context management",
+		"ip route 0.0.0.0/0 192.168.1.1",
+		"ip route 10.0.0.0/8 Vlan10 10.10.0.254 name core tag 100 250",
+		"ip prefix-list LOOPBACKS seq 5 permit 10.255.0.0/16 le 32",
+		"ip prefix-list LOOPBACKS seq 10 deny 0.0.0.0/0 le 32",
+		"route-map EXPORT permit 10",
+		"match ip address prefix-list LOOPBACKS",
+		"set local-preference 200",
+		"route-map EXPORT deny 20",
+		"router bgp 65001",
+		"neighbor 10.0.0.2",
+		"remote-as 65002",
+		"description spine-1",
+		"update-source loopback0",
+		"address-family ipv4 unicast",
+		"send-community",
+		"send-community extended",
+		"route-map EXPORT out",
+		"router bgp 65001",
+		"neighbor 10.0.0.2",
+		"address-family l2vpn evpn",
+		"send-community",
+		"send-community extended",
+		"route-map EXPORT out",
+	}
+	if !reflect.DeepEqual(exp, cmds) {
+		t.Fatalf("unexpected commands: %q", cmds)
+	}
+
+	for i, b := range []ConfigBuilder{
+		&VlanSpec{ID: 4095},
+		&InterfaceSpec{Name: "e1/1", AccessVlan: 5000},
+		&InterfaceSpec{Name: "gi0/1"},
+		&SVIConfig{Vlan: 10, Addresses: []string{"10.10.0.1"}},
+		&PortChannelConfig{ID: 20, Members: []string{"po10"}},
+		&StaticRoute{Prefix: "10.0.0.1/8", NextHop: "192.168.1.1"},
+		&StaticRoute{Prefix: "10.0.0.0/8"},
+		&StaticRoute{Prefix: "10.0.0.0/8", NextHop: "2001:db8::1"},
+		&StaticRoute{Prefix: "10.0.0.0/8", NextHop: "192.168.1.1", Distance: 256},
+		&PrefixList{Name: "LOOPBACKS", Entries: []*PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "10.255.0.0/16", GE: 8}}},
+		&PrefixList{Name: "LOOPBACKS", Entries: []*PrefixListEntry{{Seq: 5, Action: "permit", Prefix: "10.255.0.0/16", GE: 28, LE: 24}}},
+		&PrefixList{Name: "LOOPBACKS", Entries: []*PrefixListEntry{{Seq: 5, Action: "allow", Prefix: "10.255.0.0/16"}}},
+		&RouteMap{Name: "EXPORT", Entries: []*RouteMapEntry{{Seq: 10, Action: "permit"}, {Seq: 10, Action: "deny"}}},
+		&RouteMap{Name: "EXPORT", Entries: []*RouteMapEntry{{Seq: 10, Action: "permit", Set: []string{""}}}},
+		&BGPNeighbor{ASN: "65001", Address: "10.0.0.256", RemoteAS: "65002"},
+		&BGPNeighbor{ASN: "4294967296", Address: "10.0.0.2", RemoteAS: "65002"},
+		&BGPNeighbor{ASN: "65001", Address: "10.0.0.2", RemoteAS: "65002", AddressFamilies: []string{"vpnv4"}},
+		&VlanSpec{ID: 10, Name: "SERVERS\nno feature bgp"},
+		&InterfaceSpec{Name: "e1/1", Description: "uplink\nno feature bgp"},
+		&SVIConfig{Vlan: 10, Description: "servers\rno feature bgp"},
+		&PortChannelConfig{ID: 20, Description: "uplink\nno feature lacp"},
+		&RouteMap{Name: "EXPORT", Entries: []*RouteMapEntry{{Seq: 10, Action: "permit", Set: []string{"community 65001:1\nno router bgp 65001"}}}},
+		&BGPNeighbor{ASN: "65001", Address: "10.0.0.2", RemoteAS: "65002", Description: "x\nno router bgp 65001"},
+		&BGPNeighbor{ASN: "65001", Address: "10.0.0.2", RemoteAS: "65002", Description: strings.Repeat("x", 81)},
+	} {
+		if _, err := BuildConfig(b); err == nil {
+			t.Fatalf("test %d: expected error for invalid configuration", i)
+		}
+	}
+
+	cmds, err = BuildConfig(
+		&StaticRoute{Prefix: "2001:db8::/32", NextHop: "2001:db8:ffff::1"},
+		&BGPNeighbor{ASN: "1.10", VRF: "tenant-1", Address: "2001:db8::2", RemoteAS: "65002", Shutdown: true},
+	)
+	if err != nil {
+		t.Fatalf("failed building configuration: %v", err)
+	}
+	exp = []string{
+		"ipv6 route 2001:db8::/32 2001:db8:ffff::1",
+		"router bgp 1.10",
+		"vrf tenant-1",
+		"neighbor 2001:db8::2",
+		"remote-as 65002",
+		"shutdown",
+		"address-family ipv6 unicast",
+	}
+	if !reflect.DeepEqual(exp, cmds) {
+		t.Fatalf("unexpected commands: %q", cmds)
+	}
+}
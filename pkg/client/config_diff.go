@@ -0,0 +1,373 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The actions of ConfigChange.
+const (
+	ConfigLineAdded   = "added"
+	ConfigLineRemoved = "removed"
+)
+
+// configReplaceableKeys are the commands taking a single value. A new
+// value of such command replaces the existing one, therefore the existing
+// one does not have to be removed first.
+var configReplaceableKeys = []string{
+	"bandwidth",
+	"channel-group",
+	"delay",
+	"description",
+	"duplex",
+	"hostname",
+	"ip address",
+	"mtu",
+	"name",
+	"remote-as",
+	"router-id",
+	"spanning-tree port type",
+	"speed",
+	"switchport access vlan",
+	"switchport mode",
+	"switchport trunk allowed vlan",
+	"switchport trunk native vlan",
+	"update-source",
+	"vrf member",
+}
+
+// ConfigChange is a line added or removed from a configuration. The Path
+// is the text of the sections containing the line, and the children of a
+// line are added or removed with the line.
+type ConfigChange struct {
+	Action string      `json:"action" xml:"action"`
+	Path   []string    `json:"path" xml:"path"`
+	Line   *ConfigLine `json:"line" xml:"line"`
+}
+
+// ConfigDiff is the difference between two configurations. The lines are
+// compared within their sections regardless of their order, because the
+// order of the commands in NX-OS configuration is not significant.
+type ConfigDiff struct {
+	Changes []*ConfigChange `json:"changes" xml:"changes"`
+}
+
+// DiffConfigurations returns the difference between two configurations,
+// e.g. the running and the startup configuration. The comments, including
+// the timestamps in the header of the configuration, are ignored.
+func DiffConfigurations(from, to *Configuration) (*ConfigDiff, error) {
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+	return DiffConfigTrees(fromTree, toTree), nil
+}
+
+// DiffConfigTrees returns the difference between two configuration trees.
+func DiffConfigTrees(from, to *ConfigTree) *ConfigDiff {
+	d := &ConfigDiff{
+		Changes: []*ConfigChange{},
+	}
+	d.diff(nil, from.Lines, to.Lines)
+	return d
+}
+
+func (d *ConfigDiff) diff(path []string, from, to []*ConfigLine) {
+	var common [][2]*ConfigLine
+	for _, line := range from {
+		if getConfigLine(to, line.Text) == nil {
+			d.add(ConfigLineRemoved, path, line)
+		}
+	}
+	for _, line := range to {
+		existing := getConfigLine(from, line.Text)
+		if existing == nil {
+			d.add(ConfigLineAdded, path, line)
+			continue
+		}
+		common = append(common, [2]*ConfigLine{existing, line})
+	}
+	for _, pair := range common {
+		d.diff(append(path[:len(path):len(path)], pair[1].Text), pair[0].Children, pair[1].Children)
+	}
+}
+
+func (d *ConfigDiff) add(action string, path []string, line *ConfigLine) {
+	d.Changes = append(d.Changes, &ConfigChange{
+		Action: action,
+		Path:   path,
+		Line:   line,
+	})
+}
+
+// IsEmpty returns true when the configurations are the same.
+func (d *ConfigDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Unified returns the difference in the format of a unified diff, with the
+// sections of the changed lines as the context, e.g.
+//
+//	--- running-config
+//	+++ startup-config
+//	 interface Ethernet1/1
+//	-  description server-01
+//	+  description server-02
+func (d *ConfigDiff) Unified(fromName, toName string) string {
+	var sb strings.Builder
+	if d.IsEmpty() {
+		return ""
+	}
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	var context []string
+	for _, c := range d.Changes {
+		if !equalConfigPaths(context, c.Path) {
+			for i, text := range c.Path {
+				if i < len(context) && context[i] == text {
+					continue
+				}
+				writeConfigDiffLine(&sb, " ", text, i)
+			}
+			context = c.Path
+		}
+		prefix := "+"
+		if c.Action == ConfigLineRemoved {
+			prefix = "-"
+		}
+		writeConfigDiffTree(&sb, prefix, c.Line, len(c.Path))
+	}
+	return sb.String()
+}
+
+func writeConfigDiffLine(sb *strings.Builder, prefix, text string, depth int) {
+	for _, s := range strings.Split(text, "\n") {
+		sb.WriteString(prefix)
+		sb.WriteString(strings.Repeat(configIndent, depth))
+		sb.WriteString(s)
+		sb.WriteString("\n")
+	}
+}
+
+func writeConfigDiffTree(sb *strings.Builder, prefix string, line *ConfigLine, depth int) {
+	writeConfigDiffLine(sb, prefix, line.Text, depth)
+	for _, child := range line.Children {
+		writeConfigDiffTree(sb, prefix, child, depth+1)
+	}
+}
+
+// Commands returns the configuration commands transforming the first
+// configuration into the second one. The lines removed are negated with
+// "no", unless they are replaced by a new value, and the physical
+// interfaces are reset with "default interface".
+func (d *ConfigDiff) Commands() []string {
+	e := &configCommandEmitter{}
+	for i := 0; i < len(d.Changes); {
+		// the changes of a section are next to each other.
+		j := i
+		for j < len(d.Changes) && equalConfigPaths(d.Changes[j].Path, d.Changes[i].Path) {
+			j++
+		}
+		group := d.Changes[i:j]
+		// the removal of a vlan from the vlan list and of its section,
+		// e.g. "vlan 20", produce the same command.
+		negated := make(map[string]bool)
+		for _, c := range group {
+			if c.Action != ConfigLineRemoved {
+				continue
+			}
+			cmd := negateConfigLine(c, group)
+			if cmd == "" || negated[cmd] {
+				continue
+			}
+			negated[cmd] = true
+			e.emit(c.Path, cmd)
+		}
+		for _, c := range group {
+			if c.Action == ConfigLineAdded {
+				e.emitTree(c.Path, c.Line)
+			}
+		}
+		i = j
+	}
+	return e.commands
+}
+
+// negateConfigLine returns the command removing a line, or an empty
+// string when the line is replaced by one of the added lines.
+func negateConfigLine(removed *ConfigChange, group []*ConfigChange) string {
+	text := removed.Line.Text
+	// the top level vlan list, e.g. "vlan 1,10,20", is replaced with the
+	// new list, and the vlans missing from it are removed.
+	if len(removed.Path) == 0 {
+		if oldVlans, ok := parseConfigVlanList(removed.Line); ok {
+			if newVlans, ok := addedConfigVlanList(group, isConfigVlanList(text)); ok {
+				keep := make(map[int]bool)
+				for _, vlan := range newVlans {
+					keep[vlan] = true
+				}
+				var deleted []int
+				for _, vlan := range oldVlans {
+					if !keep[vlan] {
+						deleted = append(deleted, vlan)
+					}
+				}
+				if len(deleted) == 0 {
+					return ""
+				}
+				return "no vlan " + formatVlanRange(deleted)
+			}
+		}
+	}
+	for _, c := range group {
+		if c.Action != ConfigLineAdded {
+			continue
+		}
+		added := c.Line.Text
+		if added == "no "+text || text == "no "+added {
+			return ""
+		}
+		if key := configReplaceableKey(text); key != "" && key == configReplaceableKey(added) {
+			return ""
+		}
+	}
+	switch {
+	case strings.HasPrefix(text, "no "):
+		return strings.TrimPrefix(text, "no ")
+	case strings.HasPrefix(text, "banner "):
+		fields := strings.Fields(text)
+		return "no banner " + fields[1]
+	case len(removed.Path) == 0 && isConfigPhysicalInterface(text):
+		return "default " + text
+	}
+	return "no " + text
+}
+
+func configReplaceableKey(text string) string {
+	if strings.HasSuffix(text, " secondary") {
+		return ""
+	}
+	for _, key := range configReplaceableKeys {
+		if strings.HasPrefix(text, key+" ") {
+			return key
+		}
+	}
+	return ""
+}
+
+func isConfigVlanList(text string) bool {
+	return strings.HasPrefix(text, "vlan ") && strings.ContainsAny(text, ",-")
+}
+
+// parseConfigVlanList returns the vlans of a vlan line without children,
+// e.g. "vlan 1,10,20" or "vlan 1".
+func parseConfigVlanList(line *ConfigLine) ([]int, bool) {
+	if line.IsSection() || !strings.HasPrefix(line.Text, "vlan ") {
+		return nil, false
+	}
+	vlans, err := parseVlanRange(strings.TrimPrefix(line.Text, "vlan "))
+	if err != nil {
+		return nil, false
+	}
+	return vlans, true
+}
+
+// addedConfigVlanList returns the vlans of the vlan list added at the top
+// level. A single vlan, e.g. "vlan 1", is the list only when the removed
+// line is a list, e.g. "vlan 1,10".
+func addedConfigVlanList(group []*ConfigChange, isList bool) ([]int, bool) {
+	var single []int
+	for _, c := range group {
+		if c.Action != ConfigLineAdded || len(c.Path) != 0 {
+			continue
+		}
+		vlans, ok := parseConfigVlanList(c.Line)
+		if !ok {
+			continue
+		}
+		if isConfigVlanList(c.Line.Text) {
+			return vlans, true
+		}
+		if single == nil {
+			single = vlans
+		}
+	}
+	if isList && single != nil {
+		return single, true
+	}
+	return nil, false
+}
+
+// isConfigPhysicalInterface returns true for the interfaces that cannot
+// be removed, e.g. "interface Ethernet1/1".
+func isConfigPhysicalInterface(text string) bool {
+	if !strings.HasPrefix(text, "interface ") {
+		return false
+	}
+	name := strings.ToLower(strings.TrimPrefix(text, "interface "))
+	return strings.HasPrefix(name, "ethernet") || strings.HasPrefix(name, "mgmt")
+}
+
+func equalConfigPaths(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// configCommandEmitter tracks the configuration mode of the emitted
+// commands, and enters the sections of a command when the mode changes.
+type configCommandEmitter struct {
+	commands []string
+	mode     []string
+}
+
+func (e *configCommandEmitter) emit(path []string, cmd string) {
+	if !equalConfigPaths(e.mode, path) {
+		// a top level command exits any configuration mode, and the
+		// nested sections are entered from the top level.
+		e.commands = append(e.commands, path...)
+	}
+	// a banner spans multiple lines, and is entered line by line, because
+	// Configure takes a command per line.
+	if strings.HasPrefix(cmd, "banner ") {
+		e.commands = append(e.commands, strings.Split(cmd, "\n")...)
+	} else {
+		e.commands = append(e.commands, cmd)
+	}
+	e.mode = path
+}
+
+func (e *configCommandEmitter) emitTree(path []string, line *ConfigLine) {
+	e.emit(path, line.Text)
+	if !line.IsSection() {
+		return
+	}
+	childPath := append(path[:len(path):len(path)], line.Text)
+	e.mode = childPath
+	for _, child := range line.Children {
+		e.emitTree(childPath, child)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffConfigurations(t *testing.T) {
+	var confs []*Configuration
+	for _, fp := range []string{
+		"../../assets/requests/resp.show.running.config.2.json",
+		"../../assets/requests/resp.show.startup.config.1.json",
+	} {
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Fatalf("failed reading '%s', error: %v", fp, err)
+		}
+		conf, err := NewConfigurationFromBytes(content)
+		if err != nil {
+			t.Fatalf("failed parsing '%s', error: %v", fp, err)
+		}
+		confs = append(confs, conf)
+	}
+
+	diff, err := DiffConfigurations(confs[0], confs[0])
+	if err != nil {
+		t.Fatalf("failed comparing configurations: %v", err)
+	}
+	if !diff.IsEmpty() || diff.Unified("a", "b") != "" || len(diff.Commands()) != 0 {
+		t.Fatalf("unexpected difference: %v", diff.Changes)
+	}
+
+	diff, err = DiffConfigurations(confs[0], confs[1])
+	if err != nil {
+		t.Fatalf("failed comparing configurations: %v", err)
+	}
+	expUnified := strings.Join([]string{
+		"--- running-config",
+		"+++ startup-config",
+		"-vlan 1,10,20",
+		"-vlan 20",
+		"-  name STORAGE",
+		"-interface Vlan10",
+		"-  no shutdown",
+		"-  vrf member RED",
+		"-  ip address 10.10.10.1/24",
+		"+vlan 1,10,30",
+		"+vlan 30",
+		"+  name BACKUP",
+		"+interface Ethernet1/4",
+		"+  description backup",
+		"+  switchport access vlan 30",
+		" interface Ethernet1/1",
+		"-  description server-01",
+		"-  spanning-tree port type edge",
+		"+  description server-02",
+		" interface Ethernet1/3",
+		"-  shutdown",
+		"+  no shutdown",
+		" router bgp 65001",
+		"+  neighbor 10.0.0.2",
+		"+    remote-as 65000",
+		"   neighbor 10.0.0.1",
+		"-    remote-as 65000",
+		"+    remote-as 65002",
+	}, "\n") + "\n"
+	if unified := diff.Unified("running-config", "startup-config"); unified != expUnified {
+		t.Fatalf("unexpected unified diff:\n%s", unified)
+	}
+	expCommands := []string{
+		"no vlan 20",
+		"no interface Vlan10",
+		"vlan 1,10,30",
+		"vlan 30",
+		"name BACKUP",
+		"interface Ethernet1/4",
+		"description backup",
+		"switchport access vlan 30",
+		"interface Ethernet1/1",
+		"no spanning-tree port type edge",
+		"description server-02",
+		"interface Ethernet1/3",
+		"no shutdown",
+		"router bgp 65001",
+		"neighbor 10.0.0.2",
+		"remote-as 65000",
+		"router bgp 65001",
+		"neighbor 10.0.0.1",
+		"remote-as 65002",
+	}
+	if commands := diff.Commands(); !reflect.DeepEqual(commands, expCommands) {
+		t.Fatalf("unexpected commands:\n%s", strings.Join(commands, "\n"))
+	}
+}
+
+func TestDiffConfigTreesRemovals(t *testing.T) {
+	from, err := NewConfigTreeFromString("interface Ethernet1/5\n  description old\nno ip domain-lookup\nbanner motd #\nhello\n#\n")
+	if err != nil {
+		t.Fatalf("failed parsing configuration tree: %v", err)
+	}
+	to, err := NewConfigTreeFromString("ip domain-lookup\n")
+	if err != nil {
+		t.Fatalf("failed parsing configuration tree: %v", err)
+	}
+	diff := DiffConfigTrees(from, to)
+	exp := []string{
+		"default interface Ethernet1/5",
+		"no banner motd",
+		"ip domain-lookup",
+	}
+	if commands := diff.Commands(); !reflect.DeepEqual(commands, exp) {
+		t.Fatalf("unexpected commands: %v", commands)
+	}
+}
+
+func TestDiffConfigTreesVlanList(t *testing.T) {
+	for i, test := range []struct {
+		from string
+		to   string
+		exp  []string
+	}{
+		{
+			from: "vlan 1,10\nvlan 10\n  name SERVERS\n",
+			to:   "vlan 1\n",
+			exp:  []string{"no vlan 10", "vlan 1"},
+		},
+		{
+			from: "vlan 1\n",
+			to:   "vlan 1,10\nvlan 10\n  name SERVERS\n",
+			exp:  []string{"vlan 1,10", "vlan 10", "name SERVERS"},
+		},
+		{
+			from: "vlan 1,10-12\n",
+			to:   "vlan 1,11\n",
+			exp:  []string{"no vlan 10,12", "vlan 1,11"},
+		},
+	} {
+		from, err := NewConfigTreeFromString(test.from)
+		if err != nil {
+			t.Fatalf("test %d: failed parsing configuration tree: %v", i, err)
+		}
+		to, err := NewConfigTreeFromString(test.to)
+		if err != nil {
+			t.Fatalf("test %d: failed parsing configuration tree: %v", i, err)
+		}
+		if commands := DiffConfigTrees(from, to).Commands(); !reflect.DeepEqual(commands, test.exp) {
+			t.Fatalf("test %d: unexpected commands: %q", i, commands)
+		}
+	}
+}
+
+func TestDiffConfigTreesBanner(t *testing.T) {
+	from, err := NewConfigTreeFromString("hostname sw01\n")
+	if err != nil {
+		t.Fatalf("failed parsing configuration tree: %v", err)
+	}
+	to, err := NewConfigTreeFromString("hostname sw01\nbanner motd #\nAuthorized access only\nDisconnect now\n#\n")
+	if err != nil {
+		t.Fatalf("failed parsing configuration tree: %v", err)
+	}
+	exp := []string{
+		"banner motd #",
+		"Authorized access only",
+		"Disconnect now",
+		"#",
+	}
+	if commands := DiffConfigTrees(from, to).Commands(); !reflect.DeepEqual(commands, exp) {
+		t.Fatalf("unexpected commands: %q", commands)
+	}
+}
+
+func TestConfigCommandEmitterNewline(t *testing.T) {
+	// only a banner is split into lines, any other command with a newline
+	// stays a single command, rather than injecting another one.
+	line := &ConfigLine{
+		Text:     "interface Ethernet1/1",
+		Children: []*ConfigLine{{Text: "description uplink\nno feature bgp"}},
+	}
+	exp := []string{"interface Ethernet1/1", "description uplink\nno feature bgp"}
+	if commands := renderConfigLines(line); !reflect.DeepEqual(commands, exp) {
+		t.Fatalf("unexpected commands: %q", commands)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"regexp"
+	"strings"
+)
+
+var configReplaceFailureRegex = regexp.MustCompile(`(?i)error|fail`)
+
+// ConfigReplaceResult is the result of the replacement of the running
+// configuration with a file. The information in the structure is from
+// the output of "configure replace" command. When CommitRequired, the
+// replacement is rolled back unless committed with ConfigReplaceCommit
+// within the commit timeout.
+type ConfigReplaceResult struct {
+	File           string   `json:"file" xml:"file"`
+	CommitTimeout  int      `json:"commit_timeout" xml:"commit_timeout"`
+	Success        bool     `json:"success" xml:"success"`
+	Verified       bool     `json:"verified" xml:"verified"`
+	CommitRequired bool     `json:"commit_required" xml:"commit_required"`
+	Errors         []string `json:"errors" xml:"errors"`
+	Output         string   `json:"output" xml:"output"`
+}
+
+// NewConfigReplaceResultFromString returns ConfigReplaceResult instance
+// from an input string.
+func NewConfigReplaceResultFromString(s string) (*ConfigReplaceResult, error) {
+	return NewConfigReplaceResultFromBytes([]byte(s))
+}
+
+// NewConfigReplaceResultFromBytes returns ConfigReplaceResult instance
+// from an input byte array.
+func NewConfigReplaceResultFromBytes(s []byte) (*ConfigReplaceResult, error) {
+	body, err := newCLIOutputFromBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfigReplaceResult(body), nil
+}
+
+// parseConfigReplaceResult parses the output of "configure replace", e.g.
+//
+//	Collecting Running-Config
+//	Converting to checkpoint file
+//	#Generating Rollback Patch
+//	Executing Rollback Patch
+//	Generating Running-config for verification
+//	Generating Patch for verification
+//	Verification is Successful.
+//	Configure replace completed successfully. Please run 'configure replace commit' within 60 seconds to commit the configuration.
+func parseConfigReplaceResult(s string) *ConfigReplaceResult {
+	result := &ConfigReplaceResult{
+		Errors: []string{},
+		Output: strings.TrimSpace(s),
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "Note:"):
+		case strings.HasPrefix(line, "Verification is Successful"):
+			result.Verified = true
+		case strings.Contains(line, "replace completed successfully"):
+			result.Success = true
+			result.CommitRequired = strings.Contains(line, "'configure replace commit'")
+		case configReplaceFailureRegex.MatchString(line):
+			result.Errors = append(result.Errors, line)
+		}
+	}
+	if len(result.Errors) > 0 {
+		result.Success = false
+		result.Verified = false
+		result.CommitRequired = false
+	}
+	return result
+}
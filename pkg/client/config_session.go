@@ -0,0 +1,196 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	configSessionLineRegex    = regexp.MustCompile(`^\d{4}  ?(.*)$`)
+	configSessionFailureRegex = regexp.MustCompile(`(?i)error|fail|invalid`)
+)
+
+// ConfigSession is a configuration session. The commands added to the
+// session are staged on the device until the session is committed or
+// aborted.
+type ConfigSession struct {
+	Name string `json:"name" xml:"name"`
+	cli  *Client
+}
+
+// ConfigSessionVerification is the result of the verification of a
+// configuration session.
+type ConfigSessionVerification struct {
+	Success bool     `json:"success" xml:"success"`
+	Errors  []string `json:"errors" xml:"errors"`
+	Output  string   `json:"output" xml:"output"`
+}
+
+// OpenConfigSession opens a configuration session ("configure session").
+// An existing session with the same name is reopened with its commands.
+func (cli *Client) OpenConfigSession(name string) (*ConfigSession, error) {
+	if name == "" || strings.Contains(name, " ") || hasControlChars(name) {
+		return nil, fmt.Errorf("invalid session name: %q", name)
+	}
+	s := &ConfigSession{
+		Name: name,
+		cli:  cli,
+	}
+	if _, err := s.run("end"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add stages commands in the session.
+func (s *ConfigSession) Add(cmds ...string) error {
+	if len(cmds) == 0 {
+		return fmt.Errorf("empty input")
+	}
+	_, err := s.run(cmds...)
+	return err
+}
+
+// Verify verifies the commands of the session against the running
+// configuration ("verify"). A failed verification is reported in
+// ConfigSessionVerification.
+func (s *ConfigSession) Verify() (*ConfigSessionVerification, error) {
+	resp, err := s.cli.Configure([]string{"configure session " + s.Name, "verify"})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != 2 {
+		return nil, fmt.Errorf("unexpected number of responses: %d", len(resp))
+	}
+	if resp[0].Error != nil {
+		return nil, fmt.Errorf("failed opening session %s: %s", s.Name, jsonRPCResponseMessage(resp[0]))
+	}
+	return parseConfigSessionVerification(resp[1]), nil
+}
+
+// Diff returns the commands staged in the session ("show configuration
+// session").
+func (s *ConfigSession) Diff() (*ConfigTree, error) {
+	url := fmt.Sprintf("%s://%s:%d/ins", s.cli.protocol, s.cli.host, s.cli.port)
+	req := NewInsAPICliShowASCIIRequest("show configuration session " + s.Name)
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.cli.callAPI("json", url, payload)
+	if err != nil {
+		return nil, err
+	}
+	return NewConfigSessionTreeFromBytes(resp)
+}
+
+// Commit verifies and applies the commands of the session to the running
+// configuration ("commit"). The session is closed after the commit.
+func (s *ConfigSession) Commit() error {
+	_, err := s.run("commit")
+	return err
+}
+
+// Abort discards the commands of the session and closes it ("abort").
+func (s *ConfigSession) Abort() error {
+	_, err := s.run("abort")
+	return err
+}
+
+// run executes commands in the session and returns an error for the first
+// failed command.
+func (s *ConfigSession) run(cmds ...string) ([]JSONRPCResponse, error) {
+	cmds = append([]string{"configure session " + s.Name}, cmds...)
+	resp, err := s.cli.Configure(cmds)
+	if err != nil {
+		return nil, err
+	}
+	if err := jsonRPCResponsesError(cmds, resp); err != nil {
+		return nil, fmt.Errorf("session %s: %s", s.Name, err)
+	}
+	return resp, nil
+}
+
+func parseConfigSessionVerification(r JSONRPCResponse) *ConfigSessionVerification {
+	v := &ConfigSessionVerification{
+		Errors: []string{},
+	}
+	if r.Error != nil {
+		v.Output = strings.TrimSpace(r.Error.Data.Msg)
+		if v.Output == "" {
+			v.Output = r.Error.Message
+		}
+	} else {
+		v.Output = jsonRPCResponseMessage(r)
+	}
+	for _, line := range strings.Split(v.Output, "\n") {
+		line = strings.TrimSpace(line)
+		if configSessionFailureRegex.MatchString(line) {
+			v.Errors = append(v.Errors, line)
+		}
+	}
+	v.Success = r.Error == nil && len(v.Errors) == 0
+	if !v.Success && len(v.Errors) == 0 {
+		v.Errors = append(v.Errors, v.Output)
+	}
+	return v
+}
+
+// NewConfigSessionTreeFromString returns ConfigTree instance with the
+// commands of a configuration session from an input string.
+func NewConfigSessionTreeFromString(s string) (*ConfigTree, error) {
+	return NewConfigSessionTreeFromBytes([]byte(s))
+}
+
+// NewConfigSessionTreeFromBytes returns ConfigTree instance with the
+// commands of a configuration session from an input byte array.
+func NewConfigSessionTreeFromBytes(s []byte) (*ConfigTree, error) {
+	body, err := newCLIOutputFromBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfigSessionTree(body)
+}
+
+// parseConfigSessionTree parses the output of "show configuration
+// session", e.g.
+//
+//	config session change-42
+//	0001  interface Ethernet1/1
+//	0002    description server-01
+//
+// The commands are numbered, and indented after the number.
+func parseConfigSessionTree(s string) (*ConfigTree, error) {
+	var lines []string
+	found := false
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if strings.HasPrefix(line, "config session ") {
+			found = true
+			continue
+		}
+		if m := configSessionLineRegex.FindStringSubmatch(line); m != nil {
+			lines = append(lines, m[1])
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no configuration session found: %s", s)
+	}
+	return parseConfigTree(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,165 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseShowConfigurationSessionOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.show.configuration.session.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	tree, err := NewConfigSessionTreeFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	exp := "interface Ethernet1/1\n  description server-01\n  switchport access vlan 10\nvlan 10\n  name SERVERS\n"
+	if tree.String() != exp {
+		t.Fatalf("unexpected session commands:\n%s", tree)
+	}
+
+	fp = "../../assets/requests/resp.show.boot.1.json"
+	content, err = ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	if _, err := NewConfigSessionTreeFromBytes(content); err == nil {
+		t.Fatalf("expected error parsing '%s'", fp)
+	}
+}
+
+func TestConfigSession(t *testing.T) {
+	dataDir := "../../assets/requests"
+	verifyFile := "resp.configure.session.verify.1.json"
+	var received [][]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ins", func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !bytes.Contains(body, []byte("jsonrpc")) {
+			fc, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", dataDir, "resp.show.configuration.session.1.json"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Write(fc)
+			return
+		}
+		var j []*JSONRPCRequest
+		if err := json.Unmarshal(body, &j); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var cmds []string
+		var resp []JSONRPCResponse
+		for _, r := range j {
+			cmds = append(cmds, r.Params.Command)
+			resp = append(resp, JSONRPCResponse{Version: "2.0", Result: json.RawMessage("null"), ID: r.ID})
+		}
+		received = append(received, cmds)
+		if cmds[len(cmds)-1] == "verify" {
+			fc, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", dataDir, verifyFile))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Write(fc)
+			return
+		}
+		fc, _ := json.Marshal(resp)
+		w.Write(fc)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	addr := strings.Split(server.URL, ":")
+	port, _ := strconv.Atoi(addr[2])
+	cli := NewClient()
+	cli.SetHost("127.0.0.1")
+	cli.SetPort(port)
+	cli.SetProtocol(addr[0])
+	cli.SetUsername("admin")
+	cli.SetPassword("cisco")
+
+	for _, name := range []string{"change 42", "change\nno feature bgp"} {
+		if _, err := cli.OpenConfigSession(name); err == nil {
+			t.Fatalf("expected error for invalid session name %q", name)
+		}
+	}
+	session, err := cli.OpenConfigSession("change-42")
+	if err != nil {
+		t.Fatalf("failed opening session: %v", err)
+	}
+	if err := session.Add("interface Ethernet1/1", "description server-01"); err != nil {
+		t.Fatalf("failed adding commands: %v", err)
+	}
+	verification, err := session.Verify()
+	if err != nil {
+		t.Fatalf("failed verifying session: %v", err)
+	}
+	if !verification.Success || verification.Output != "Verification Successful" {
+		t.Fatalf("unexpected verification: %v", verification)
+	}
+	tree, err := session.Diff()
+	if err != nil {
+		t.Fatalf("failed getting session commands: %v", err)
+	}
+	if len(tree.Lines) != 2 {
+		t.Fatalf("unexpected session commands:\n%s", tree)
+	}
+	if err := session.Commit(); err != nil {
+		t.Fatalf("failed committing session: %v", err)
+	}
+
+	verifyFile = "resp.configure.session.verify.2.json"
+	verification, err = session.Verify()
+	if err != nil {
+		t.Fatalf("failed verifying session: %v", err)
+	}
+	expErrors := []string{"Failed to start Verification: Syntax error while parsing 'interface Ethernet1/99'"}
+	if verification.Success || !reflect.DeepEqual(expErrors, verification.Errors) {
+		t.Fatalf("unexpected verification: %v", verification)
+	}
+	if err := session.Abort(); err != nil {
+		t.Fatalf("failed aborting session: %v", err)
+	}
+
+	expReceived := [][]string{
+		{"configure session change-42", "end"},
+		{"configure session change-42", "interface Ethernet1/1", "description server-01"},
+		{"configure session change-42", "verify"},
+		{"configure session change-42", "commit"},
+		{"configure session change-42", "verify"},
+		{"configure session change-42", "abort"},
+	}
+	if !reflect.DeepEqual(expReceived, received) {
+		t.Fatalf("unexpected commands: %v", received)
+	}
+}
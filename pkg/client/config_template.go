@@ -0,0 +1,265 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// DeviceLoopback is a loopback interface of DeviceVars. The Address is an
+// IP prefix, e.g. "10.255.0.11/32".
+type DeviceLoopback struct {
+	ID          int    `json:"id" xml:"id"`
+	Address     string `json:"address" xml:"address"`
+	Description string `json:"description" xml:"description"`
+}
+
+// DeviceUplink is an uplink interface of DeviceVars, with its BGP peer.
+// The Address is an IP prefix, e.g. "10.0.0.1/31".
+type DeviceUplink struct {
+	Interface   string `json:"interface" xml:"interface"`
+	Description string `json:"description" xml:"description"`
+	Address     string `json:"address" xml:"address"`
+	PeerAddress string `json:"peer_address" xml:"peer_address"`
+	PeerAS      string `json:"peer_as" xml:"peer_as"`
+}
+
+// DeviceVars are the variables of a device for ConfigTemplate. The Vars
+// are any other variables, e.g. the AS number of the device.
+type DeviceVars struct {
+	Hostname  string                 `json:"hostname" xml:"hostname"`
+	Loopbacks []*DeviceLoopback      `json:"loopbacks" xml:"loopbacks"`
+	Uplinks   []*DeviceUplink        `json:"uplinks" xml:"uplinks"`
+	Vars      map[string]interface{} `json:"vars" xml:"-"`
+}
+
+// NewDeviceVarsFromFile returns DeviceVars instance from a JSON file.
+func NewDeviceVarsFromFile(fp string) (*DeviceVars, error) {
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		return nil, err
+	}
+	return NewDeviceVarsFromBytes(content)
+}
+
+// NewDeviceVarsFromString returns DeviceVars instance from a JSON string.
+func NewDeviceVarsFromString(s string) (*DeviceVars, error) {
+	return NewDeviceVarsFromBytes([]byte(s))
+}
+
+// NewDeviceVarsFromBytes returns DeviceVars instance from JSON bytes. The
+// names of the uplink interfaces are normalized, e.g. "e1/49" becomes
+// "Ethernet1/49".
+func NewDeviceVarsFromBytes(s []byte) (*DeviceVars, error) {
+	vars := &DeviceVars{}
+	if err := json.Unmarshal(s, vars); err != nil {
+		return nil, err
+	}
+	if err := vars.validate(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+func (vars *DeviceVars) validate() error {
+	if vars.Hostname == "" || strings.ContainsAny(vars.Hostname, " \t") {
+		return fmt.Errorf("invalid hostname: %q", vars.Hostname)
+	}
+	for _, lo := range vars.Loopbacks {
+		if lo.ID < 0 || lo.ID > 1023 {
+			return fmt.Errorf("invalid loopback: %d", lo.ID)
+		}
+		if _, _, err := net.ParseCIDR(lo.Address); err != nil {
+			return fmt.Errorf("loopback %d: invalid ip address: %s", lo.ID, lo.Address)
+		}
+	}
+	for _, uplink := range vars.Uplinks {
+		name, err := NormalizeInterfaceName(uplink.Interface)
+		if err != nil {
+			return err
+		}
+		uplink.Interface = name
+		if uplink.Address != "" {
+			if _, _, err := net.ParseCIDR(uplink.Address); err != nil {
+				return fmt.Errorf("uplink %s: invalid ip address: %s", name, uplink.Address)
+			}
+		}
+		if uplink.PeerAddress != "" && net.ParseIP(uplink.PeerAddress) == nil {
+			return fmt.Errorf("uplink %s: invalid peer address: %s", name, uplink.PeerAddress)
+		}
+		if uplink.PeerAS != "" && !isBGPASN(uplink.PeerAS) {
+			return fmt.Errorf("uplink %s: invalid peer as number: %s", name, uplink.PeerAS)
+		}
+	}
+	return nil
+}
+
+// ConfigTemplate is a text/template of NX-OS configuration, rendered with
+// DeviceVars. Besides the builtin functions, the template has the
+// following functions:
+//
+//	interface "e1/1"         Ethernet1/1
+//	vlans "20,10,11,12"      10-12,20
+//	address "10.0.0.1/31"    10.0.0.1
+//	prefix "10.0.0.1/31"     10.0.0.0/31
+//
+// A missing key of the Vars is an error.
+type ConfigTemplate struct {
+	Name string `json:"name" xml:"name"`
+	tmpl *template.Template
+}
+
+var configTemplateFuncs = template.FuncMap{
+	"interface": NormalizeInterfaceName,
+	"vlans": func(s string) (string, error) {
+		vlans, err := parseVlanRange(s)
+		if err != nil {
+			return "", err
+		}
+		return formatVlanRange(vlans), nil
+	},
+	"address": func(s string) (string, error) {
+		ip, _, err := net.ParseCIDR(s)
+		if err != nil {
+			return "", err
+		}
+		return ip.String(), nil
+	},
+	"prefix": func(s string) (string, error) {
+		_, prefix, err := net.ParseCIDR(s)
+		if err != nil {
+			return "", err
+		}
+		return prefix.String(), nil
+	},
+}
+
+// NewConfigTemplate returns ConfigTemplate instance from the text of a
+// template.
+func NewConfigTemplate(name, text string) (*ConfigTemplate, error) {
+	tmpl, err := template.New(name).Funcs(configTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigTemplate{Name: name, tmpl: tmpl}, nil
+}
+
+// NewConfigTemplateFromFiles returns ConfigTemplate instance from template
+// files. The first file is rendered, and the others hold the templates it
+// includes with the "template" action.
+func NewConfigTemplateFromFiles(fp ...string) (*ConfigTemplate, error) {
+	if len(fp) == 0 {
+		return nil, fmt.Errorf("no template files")
+	}
+	name := filepath.Base(fp[0])
+	tmpl, err := template.New(name).Funcs(configTemplateFuncs).Option("missingkey=error").ParseFiles(fp...)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigTemplate{Name: name, tmpl: tmpl}, nil
+}
+
+// Render returns the configuration rendered with the variables of a
+// device. The configuration is validated with the configuration parser,
+// and the lines indented with tabs are errors, because the parser uses the
+// indentation with spaces to find the sections.
+func (t *ConfigTemplate) Render(vars *DeviceVars) (*Configuration, error) {
+	if err := vars.validate(); err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	if err := t.tmpl.Execute(&sb, vars); err != nil {
+		return nil, err
+	}
+	conf := &Configuration{Text: sb.String()}
+	for i, line := range strings.Split(conf.Text, "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "\t") {
+			return nil, fmt.Errorf("template %s: line %d: tab indentation", t.Name, i+1)
+		}
+	}
+	tree, err := conf.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %s", t.Name, err)
+	}
+	if len(tree.Lines) == 0 {
+		return nil, fmt.Errorf("template %s: empty configuration", t.Name)
+	}
+	return conf, nil
+}
+
+// DiffManagedConfigTrees returns the difference between the running
+// configuration and a partial configuration, e.g. a rendered template.
+// The top level lines of the running configuration absent from the
+// partial one are not managed by it, and are not removed. Within the
+// sections of the partial configuration, e.g. "router bgp 65001", the
+// lines absent from it are removed only with prune, otherwise only the
+// lines replaced by the partial configuration are.
+func DiffManagedConfigTrees(running, managed *ConfigTree, prune bool) *ConfigDiff {
+	scope := &ConfigTree{
+		Lines: []*ConfigLine{},
+	}
+	for _, line := range running.Lines {
+		if getConfigLine(managed.Lines, line.Text) != nil {
+			scope.Lines = append(scope.Lines, line)
+		}
+	}
+	diff := DiffConfigTrees(scope, managed)
+	if prune {
+		return diff
+	}
+	changes := []*ConfigChange{}
+	for _, c := range diff.Changes {
+		if c.Action == ConfigLineRemoved && len(c.Path) > 0 {
+			var group []*ConfigChange
+			for _, other := range diff.Changes {
+				if equalConfigPaths(other.Path, c.Path) {
+					group = append(group, other)
+				}
+			}
+			// the lines not replaced, e.g. "shutdown" by "no shutdown"
+			// or "description a" by "description b", are kept.
+			if negateConfigLine(c, group) != "" {
+				continue
+			}
+		}
+		changes = append(changes, c)
+	}
+	diff.Changes = changes
+	return diff
+}
+
+// ConfigPushOptions are the options of PushConfig. With the Diff option,
+// only the difference from the running configuration is pushed, with the
+// Prune option, the difference removes the lines absent from the sections
+// of the configuration, and with the DryRun option, nothing is pushed.
+type ConfigPushOptions struct {
+	Diff   bool `json:"diff" xml:"diff"`
+	Prune  bool `json:"prune" xml:"prune"`
+	DryRun bool `json:"dry_run" xml:"dry_run"`
+}
+
+// ConfigPushResult is the result of PushConfig. The Diff is set with the
+// Diff option.
+type ConfigPushResult struct {
+	Commands []string    `json:"commands" xml:"commands"`
+	Diff     *ConfigDiff `json:"diff,omitempty" xml:"diff,omitempty"`
+	Applied  bool        `json:"applied" xml:"applied"`
+}
@@ -0,0 +1,191 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigTemplate(t *testing.T) {
+	tmpl, err := NewConfigTemplateFromFiles("../../assets/templates/leaf.tmpl")
+	if err != nil {
+		t.Fatalf("failed parsing template: %v", err)
+	}
+	vars, err := NewDeviceVarsFromFile("../../assets/templates/leaf.json")
+	if err != nil {
+		t.Fatalf("failed parsing variables: %v", err)
+	}
+	if vars.Uplinks[0].Interface != "Ethernet1/49" {
+		t.Fatalf("unexpected uplink interface: %s", vars.Uplinks[0].Interface)
+	}
+	conf, err := tmpl.Render(vars)
+	if err != nil {
+		t.Fatalf("failed rendering template: %v", err)
+	}
+	tree, err := conf.Tree()
+	if err != nil {
+		t.Fatalf("failed parsing configuration: %v", err)
+	}
+	if line := tree.Get("router bgp 65001", "router-id 10.255.0.11"); line == nil {
+		t.Fatalf("router-id not found in configuration:\n%s", conf.Text)
+	}
+	if line := tree.Get("router bgp 65001", "neighbor 10.0.2.0", "description ny-spine02"); line == nil {
+		t.Fatalf("neighbor not found in configuration:\n%s", conf.Text)
+	}
+	if line := tree.Get("interface Ethernet1/50", "ip address 10.0.2.1/31"); line == nil {
+		t.Fatalf("uplink not found in configuration:\n%s", conf.Text)
+	}
+
+	fp := "../../assets/requests/resp.show.running.config.2.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	running, err := NewConfigurationFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	runningTree, err := running.Tree()
+	if err != nil {
+		t.Fatalf("failed parsing configuration: %v", err)
+	}
+	for _, prune := range []bool{false, true} {
+		cmds := DiffManagedConfigTrees(runningTree, tree, prune).Commands()
+		found := make(map[string]bool)
+		for _, cmd := range cmds {
+			found[cmd] = true
+		}
+		for _, cmd := range []string{"hostname ny-leaf01", "router-id 10.255.0.11"} {
+			if !found[cmd] {
+				t.Fatalf("prune %t: command %q not found in commands: %q", prune, cmd, cmds)
+			}
+		}
+		// the lines absent from the sections of the template are removed
+		// only with prune.
+		for _, cmd := range []string{"no neighbor 10.0.0.1", "no network 10.10.10.0/24", "no vrf RED"} {
+			if found[cmd] != prune {
+				t.Fatalf("prune %t: unexpected commands: %q", prune, cmds)
+			}
+		}
+		// the sections absent from the template are not removed.
+		for _, cmd := range cmds {
+			if strings.HasPrefix(cmd, "no vlan") || strings.HasPrefix(cmd, "default interface") {
+				t.Fatalf("prune %t: unexpected command: %s", prune, cmd)
+			}
+		}
+	}
+	if diff := DiffManagedConfigTrees(tree, tree, true); !diff.IsEmpty() {
+		t.Fatalf("unexpected changes: %q", diff.Commands())
+	}
+
+	for i, text := range []string{
+		"hostname {{ .Vars.missing }}",
+		"interface {{ interface \"gi0/1\" }}",
+		"banner motd #\nunterminated",
+		"interface Ethernet1/1\n\tshutdown",
+		"{{ if false }}hostname {{ .Hostname }}{{ end }}",
+	} {
+		tmpl, err := NewConfigTemplate("test", text)
+		if err != nil {
+			t.Fatalf("test %d: failed parsing template: %v", i, err)
+		}
+		if _, err := tmpl.Render(vars); err == nil {
+			t.Fatalf("test %d: expected error for invalid template", i)
+		}
+	}
+
+	for i, s := range []string{
+		`{"hostname": ""}`,
+		`{"hostname": "sw01", "loopbacks": [{"id": 0, "address": "10.255.0.11"}]}`,
+		`{"hostname": "sw01", "uplinks": [{"interface": "gi0/1"}]}`,
+		`{"hostname": "sw01", "uplinks": [{"interface": "e1/1", "peer_as": "as65000"}]}`,
+	} {
+		if _, err := NewDeviceVarsFromString(s); err == nil {
+			t.Fatalf("test %d: expected error for invalid variables", i)
+		}
+	}
+}
+
+func TestConfigTemplateFuncs(t *testing.T) {
+	tmpl, err := NewConfigTemplate("test", strings.Join([]string{
+		"interface {{ interface .Vars.port }}",
+		"  switchport trunk allowed vlan {{ vlans .Vars.vlans }}",
+	}, "\n"))
+	if err != nil {
+		t.Fatalf("failed parsing template: %v", err)
+	}
+	conf, err := tmpl.Render(&DeviceVars{
+		Hostname: "sw01",
+		Vars:     map[string]interface{}{"port": "e1/1", "vlans": "20,10,11,12"},
+	})
+	if err != nil {
+		t.Fatalf("failed rendering template: %v", err)
+	}
+	tree, err := conf.Tree()
+	if err != nil {
+		t.Fatalf("failed parsing configuration: %v", err)
+	}
+	exp := []string{"interface Ethernet1/1", "switchport trunk allowed vlan 10-12,20"}
+	if cmds := renderConfigLines(tree.Lines...); !reflect.DeepEqual(exp, cmds) {
+		t.Fatalf("unexpected commands: %q", cmds)
+	}
+}
+
+func TestDiffManagedConfigTreesPartialSection(t *testing.T) {
+	running, err := NewConfigTreeFromString("router bgp 65001\n  router-id 10.0.0.11\n  neighbor 10.0.0.1\n    remote-as 65000\n  vrf RED\n    address-family ipv4 unicast\ninterface Ethernet1/1\n  description server-01\n  shutdown\n")
+	if err != nil {
+		t.Fatalf("failed parsing configuration: %v", err)
+	}
+	managed, err := NewConfigTreeFromString("router bgp 65001\n  neighbor 10.0.2.0\n    remote-as 65100\ninterface Ethernet1/1\n  description server-02\n  no shutdown\n")
+	if err != nil {
+		t.Fatalf("failed parsing configuration: %v", err)
+	}
+	for _, test := range []struct {
+		prune bool
+		exp   []string
+	}{
+		{
+			exp: []string{
+				"router bgp 65001",
+				"neighbor 10.0.2.0",
+				"remote-as 65100",
+				"interface Ethernet1/1",
+				"description server-02",
+				"no shutdown",
+			},
+		},
+		{
+			prune: true,
+			exp: []string{
+				"router bgp 65001",
+				"no router-id 10.0.0.11",
+				"no neighbor 10.0.0.1",
+				"no vrf RED",
+				"neighbor 10.0.2.0",
+				"remote-as 65100",
+				"interface Ethernet1/1",
+				"description server-02",
+				"no shutdown",
+			},
+		},
+	} {
+		if cmds := DiffManagedConfigTrees(running, managed, test.prune).Commands(); !reflect.DeepEqual(cmds, test.exp) {
+			t.Fatalf("prune %t: unexpected commands: %q", test.prune, cmds)
+		}
+	}
+}
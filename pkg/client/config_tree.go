@@ -0,0 +1,246 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// configIndent is the indentation of a configuration level.
+const configIndent = "  "
+
+// ConfigLine is a line of a configuration. The lines nested under a line,
+// e.g. "switchport mode trunk" under "interface Ethernet1/1", are its
+// children, and such line is a section.
+type ConfigLine struct {
+	Text     string        `json:"text" xml:"text"`
+	Children []*ConfigLine `json:"children,omitempty" xml:"children,omitempty"`
+	parent   *ConfigLine
+}
+
+// ConfigTree is a hierarchical representation of a configuration. The
+// comments, i.e. the lines starting with "!", and the blank lines are not
+// part of the tree.
+type ConfigTree struct {
+	Lines []*ConfigLine `json:"lines" xml:"lines"`
+}
+
+// NewConfigTreeFromString returns ConfigTree instance from the text of a
+// configuration, e.g. the Text of Configuration.
+func NewConfigTreeFromString(s string) (*ConfigTree, error) {
+	return parseConfigTree(s)
+}
+
+// Tree returns the hierarchical representation of the configuration.
+func (c *Configuration) Tree() (*ConfigTree, error) {
+	return NewConfigTreeFromString(c.Text)
+}
+
+func parseConfigTree(s string) (*ConfigTree, error) {
+	tree := &ConfigTree{
+		Lines: []*ConfigLine{},
+	}
+	// stack holds the last line at each indentation level.
+	type level struct {
+		indent int
+		line   *ConfigLine
+	}
+	var stack []level
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	for i := 0; i < len(lines); i++ {
+		raw := strings.TrimRight(lines[i], " \t")
+		text := strings.TrimSpace(raw)
+		if text == "" || strings.HasPrefix(text, "!") {
+			continue
+		}
+		indent := len(raw) - len(strings.TrimLeft(raw, " "))
+		line := &ConfigLine{Text: text}
+		// a banner spans multiple lines until its delimiter, e.g.
+		//
+		//	banner motd #
+		//	Authorized access only
+		//	#
+		if delimiter := configBannerDelimiter(text); delimiter != "" {
+			closed := strings.Count(text, delimiter) > 1
+			for !closed {
+				i++
+				if i >= len(lines) {
+					return nil, fmt.Errorf("unterminated banner: %s", text)
+				}
+				line.Text += "\n" + strings.TrimRight(lines[i], " \t\r")
+				closed = strings.Contains(lines[i], delimiter)
+			}
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			tree.Lines = append(tree.Lines, line)
+		} else {
+			parent := stack[len(stack)-1].line
+			line.parent = parent
+			parent.Children = append(parent.Children, line)
+		}
+		stack = append(stack, level{indent: indent, line: line})
+	}
+	return tree, nil
+}
+
+// configBannerDelimiter returns the delimiter of a banner line, or an
+// empty string when the line is not a banner.
+func configBannerDelimiter(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) < 3 || fields[0] != "banner" {
+		return ""
+	}
+	return fields[2][:1]
+}
+
+// Find returns the top level lines matching a prefix, e.g. "interface"
+// matches "interface Ethernet1/1", but not "interface-vlan".
+func (t *ConfigTree) Find(prefix string) []*ConfigLine {
+	return findConfigLines(t.Lines, prefix)
+}
+
+// FindWith returns the top level sections matching a prefix and
+// containing a particular line, e.g. all interfaces with
+// "switchport mode trunk".
+func (t *ConfigTree) FindWith(prefix, child string) []*ConfigLine {
+	var sections []*ConfigLine
+	for _, line := range t.Find(prefix) {
+		if line.Has(child) {
+			sections = append(sections, line)
+		}
+	}
+	return sections
+}
+
+// Get returns the line at a path, e.g. Get("router bgp 65001", "vrf RED"),
+// or nil when there is none.
+func (t *ConfigTree) Get(path ...string) *ConfigLine {
+	if len(path) == 0 {
+		return nil
+	}
+	line := getConfigLine(t.Lines, path[0])
+	for _, text := range path[1:] {
+		if line == nil {
+			return nil
+		}
+		line = line.Child(text)
+	}
+	return line
+}
+
+// Walk calls fn for every line of the tree, depth first. When fn returns
+// false, the children of the line are skipped.
+func (t *ConfigTree) Walk(fn func(*ConfigLine) bool) {
+	walkConfigLines(t.Lines, fn)
+}
+
+// String returns the text of the configuration.
+func (t *ConfigTree) String() string {
+	var sb strings.Builder
+	for _, line := range t.Lines {
+		writeConfigLine(&sb, line, 0)
+	}
+	return sb.String()
+}
+
+// Parent returns the section the line belongs to, or nil for a top level
+// line.
+func (l *ConfigLine) Parent() *ConfigLine {
+	return l.parent
+}
+
+// IsSection returns true when the line has children.
+func (l *ConfigLine) IsSection() bool {
+	return len(l.Children) > 0
+}
+
+// Path returns the text of the line prefixed with the text of its parents.
+func (l *ConfigLine) Path() []string {
+	var path []string
+	for line := l; line != nil; line = line.parent {
+		path = append([]string{line.Text}, path...)
+	}
+	return path
+}
+
+// Child returns the child with a particular text, or nil when there is
+// none.
+func (l *ConfigLine) Child(text string) *ConfigLine {
+	return getConfigLine(l.Children, text)
+}
+
+// Has returns true when the line has a child with a particular text.
+func (l *ConfigLine) Has(text string) bool {
+	return l.Child(text) != nil
+}
+
+// Find returns the children matching a prefix.
+func (l *ConfigLine) Find(prefix string) []*ConfigLine {
+	return findConfigLines(l.Children, prefix)
+}
+
+// Add appends a child line and returns it.
+func (l *ConfigLine) Add(text string) *ConfigLine {
+	child := &ConfigLine{Text: text, parent: l}
+	l.Children = append(l.Children, child)
+	return child
+}
+
+// String returns the text of the line and its children.
+func (l *ConfigLine) String() string {
+	var sb strings.Builder
+	writeConfigLine(&sb, l, 0)
+	return sb.String()
+}
+
+func getConfigLine(lines []*ConfigLine, text string) *ConfigLine {
+	for _, line := range lines {
+		if line.Text == text {
+			return line
+		}
+	}
+	return nil
+}
+
+func findConfigLines(lines []*ConfigLine, prefix string) []*ConfigLine {
+	var found []*ConfigLine
+	for _, line := range lines {
+		if line.Text == prefix || strings.HasPrefix(line.Text, prefix+" ") {
+			found = append(found, line)
+		}
+	}
+	return found
+}
+
+func walkConfigLines(lines []*ConfigLine, fn func(*ConfigLine) bool) {
+	for _, line := range lines {
+		if fn(line) {
+			walkConfigLines(line.Children, fn)
+		}
+	}
+}
+
+func writeConfigLine(sb *strings.Builder, line *ConfigLine, depth int) {
+	sb.WriteString(strings.Repeat(configIndent, depth))
+	sb.WriteString(line.Text)
+	sb.WriteString("\n")
+	for _, child := range line.Children {
+		writeConfigLine(sb, child, depth+1)
+	}
+}
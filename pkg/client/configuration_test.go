@@ -17,6 +17,8 @@ package client
 import (
 	"fmt"
 	"io/ioutil"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -76,3 +78,82 @@ func TestParseShowRunningConfigurationOutput(t *testing.T) {
 		t.Fatalf("Failed %d tests", testFailed)
 	}
 }
+
+func TestConfigurationTree(t *testing.T) {
+	fp := "../../assets/requests/resp.show.running.config.2.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	conf, err := NewConfigurationFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	tree, err := conf.Tree()
+	if err != nil {
+		t.Fatalf("failed parsing configuration tree: %v", err)
+	}
+
+	if n := len(tree.Find("interface")); n != 6 {
+		t.Fatalf("unexpected number of interfaces: %d", n)
+	}
+	var trunks []string
+	for _, intf := range tree.FindWith("interface", "switchport mode trunk") {
+		trunks = append(trunks, intf.Text)
+	}
+	if !reflect.DeepEqual(trunks, []string{"interface port-channel10", "interface Ethernet1/2"}) {
+		t.Fatalf("unexpected trunk interfaces: %v", trunks)
+	}
+	if n := len(tree.Find("feature")); n != 3 {
+		t.Fatalf("unexpected number of features: %d", n)
+	}
+
+	line := tree.Get("router bgp 65001", "neighbor 10.0.0.1", "address-family ipv4 unicast", "send-community")
+	if line == nil {
+		t.Fatalf("failed getting bgp neighbor configuration")
+	}
+	expPath := []string{"router bgp 65001", "neighbor 10.0.0.1", "address-family ipv4 unicast", "send-community"}
+	if !reflect.DeepEqual(expPath, line.Path()) {
+		t.Fatalf("unexpected path: %v", line.Path())
+	}
+	if line.Parent().Parent().Text != "neighbor 10.0.0.1" {
+		t.Fatalf("unexpected parent: %v", line.Parent().Parent())
+	}
+	if tree.Get("router bgp 65001", "vrf BLUE") != nil {
+		t.Fatalf("unexpected vrf")
+	}
+
+	banner := tree.Get("banner motd #\nAuthorized access only\n#")
+	if banner == nil {
+		t.Fatalf("failed getting banner")
+	}
+
+	lines := 0
+	tree.Walk(func(l *ConfigLine) bool {
+		lines++
+		return !strings.HasPrefix(l.Text, "router bgp")
+	})
+	if lines != 45 {
+		t.Fatalf("unexpected number of walked lines: %d", lines)
+	}
+
+	// the rendered configuration is parsed into the same tree.
+	rendered, err := NewConfigTreeFromString(tree.String())
+	if err != nil {
+		t.Fatalf("failed parsing rendered configuration: %v", err)
+	}
+	if rendered.String() != tree.String() {
+		t.Fatalf("rendered configuration mismatch:\n%s\n%s", rendered, tree)
+	}
+	if !strings.HasPrefix(tree.String(), "version 7.0(3)I6(1) Bios:version 07.61\nhostname ny-sw01\n") {
+		t.Fatalf("unexpected rendered configuration:\n%s", tree)
+	}
+	exp := "interface Ethernet1/3\n  shutdown\n"
+	if s := tree.Get("interface Ethernet1/3").String(); s != exp {
+		t.Fatalf("unexpected rendered section: %q", s)
+	}
+
+	if _, err := NewConfigTreeFromString("banner motd #\nno end"); err == nil {
+		t.Fatalf("expected unterminated banner error")
+	}
+}
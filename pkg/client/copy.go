@@ -0,0 +1,83 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	copyProgressRegex = regexp.MustCompile(`(\d+)%`)
+	copyFailureRegex  = regexp.MustCompile(`(?i)error|fail|denied|no such file|invalid|abort|no space|not enough space`)
+)
+
+// CopyResult is the result of a file copy. The information in the
+// structure is from the output of "copy" command.
+type CopyResult struct {
+	Source      string   `json:"source" xml:"source"`
+	Destination string   `json:"destination" xml:"destination"`
+	Success     bool     `json:"success" xml:"success"`
+	Progress    int      `json:"progress" xml:"progress"`
+	Errors      []string `json:"errors" xml:"errors"`
+	Output      string   `json:"output" xml:"output"`
+}
+
+// NewCopyResultFromString returns CopyResult instance from an input string.
+func NewCopyResultFromString(s string) (*CopyResult, error) {
+	return NewCopyResultFromBytes([]byte(s))
+}
+
+// NewCopyResultFromBytes returns CopyResult instance from an input byte
+// array.
+func NewCopyResultFromBytes(s []byte) (*CopyResult, error) {
+	body, err := newCLIOutputFromBytes(s)
+	if err != nil {
+		return nil, err
+	}
+	return parseCopyResult(body), nil
+}
+
+// parseCopyResult parses the output of "copy", e.g.
+//
+//	[########################################] 100%
+//	Copy complete, now saving to disk (please wait)...
+//	Copy complete.
+//
+// The progress is the last percentage reported by the copy.
+func parseCopyResult(s string) *CopyResult {
+	result := &CopyResult{
+		Errors: []string{},
+		Output: strings.TrimSpace(s),
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if m := copyProgressRegex.FindAllStringSubmatch(line, -1); m != nil {
+			result.Progress, _ = strconv.Atoi(m[len(m)-1][1])
+		}
+		switch {
+		case strings.HasPrefix(line, "Copy complete"):
+			result.Success = true
+			result.Progress = 100
+		case strings.HasPrefix(line, "% "), copyFailureRegex.MatchString(line):
+			result.Errors = append(result.Errors, line)
+		}
+	}
+	if len(result.Errors) > 0 {
+		result.Success = false
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestParseCopyOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.copy.running-config.startup-config.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	result, err := NewCopyResultFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if !result.Success || result.Progress != 100 || len(result.Errors) != 0 {
+		t.Fatalf("unexpected copy result: %v", result)
+	}
+
+	fp = "../../assets/requests/resp.copy.bootflash.1.json"
+	content, err = ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	result, err = NewCopyResultFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	expErrors := []string{
+		"scp: /backup/nxos.7.0.3.I7.5a.bin: No space left on device",
+		"Copy failed: Error in copying file",
+	}
+	if result.Success || result.Progress != 52 || !reflect.DeepEqual(expErrors, result.Errors) {
+		t.Fatalf("unexpected copy result: %v", result)
+	}
+}
+
+func TestParseConfigureReplaceOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.configure.replace.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	result, err := NewConfigReplaceResultFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if !result.Success || !result.Verified || !result.CommitRequired || len(result.Errors) != 0 {
+		t.Fatalf("unexpected configure replace result: %v", result)
+	}
+
+	result, err = NewConfigReplaceResultFromString(`{"ins_api":{"outputs":{"output":{"code":"200",
+		"body":"Collecting Running-Config\nExecuting Rollback Patch\nSyntax error while parsing 'interface Ethernet1/99'\nVerification failed, Rolling back to previous configuration.\n"}}}}`)
+	if err != nil {
+		t.Fatalf("failed parsing failed configure replace: %v", err)
+	}
+	if result.Success || result.Verified || len(result.Errors) != 2 {
+		t.Fatalf("unexpected configure replace result: %v", result)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DHCPSnoopingBinding is an entry of the DHCP snooping binding database.
+// The information in the structure is from the output of "show ip dhcp
+// snooping binding" command. A Lease of -1 indicates an infinite lease.
+type DHCPSnoopingBinding struct {
+	MacAddress string        `json:"mac_address" xml:"mac_address"`
+	IPAddress  string        `json:"ip_address" xml:"ip_address"`
+	Lease      time.Duration `json:"lease" xml:"lease"`
+	Type       string        `json:"type" xml:"type"`
+	Vlan       int           `json:"vlan" xml:"vlan"`
+	Interface  string        `json:"interface" xml:"interface"`
+}
+
+// DHCPRelayMessageCounters are the counters of a DHCP message type of
+// DHCPRelayStatistics.
+type DHCPRelayMessageCounters struct {
+	Type     string `json:"type" xml:"type"`
+	Received uint64 `json:"received" xml:"received"`
+	Sent     uint64 `json:"sent" xml:"sent"`
+	Dropped  uint64 `json:"dropped" xml:"dropped"`
+}
+
+// DHCPRelayStatistics contains DHCP relay statistics. The information in
+// the structure is from the output of "show ip dhcp relay statistics"
+// command. The Drops are the counters of the reasons for dropping
+// packets, e.g. "Interface error".
+type DHCPRelayStatistics struct {
+	Messages []DHCPRelayMessageCounters `json:"messages" xml:"messages"`
+	Total    DHCPRelayMessageCounters   `json:"total" xml:"total"`
+	Drops    map[string]uint64          `json:"drops" xml:"drops"`
+}
+
+// NewDHCPSnoopingBindingsFromString returns DHCPSnoopingBinding instances
+// from an input string.
+func NewDHCPSnoopingBindingsFromString(s string) ([]*DHCPSnoopingBinding, error) {
+	return NewDHCPSnoopingBindingsFromBytes([]byte(s))
+}
+
+// NewDHCPSnoopingBindingsFromBytes returns DHCPSnoopingBinding instances
+// from an input byte array.
+func NewDHCPSnoopingBindingsFromBytes(s []byte) ([]*DHCPSnoopingBinding, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseDHCPSnoopingBindings(resp.Result.Outputs.Output.Body)
+}
+
+// parseDHCPSnoopingBindings parses the text output of "show ip dhcp
+// snooping binding", e.g.
+//
+//	MacAddress         IpAddress        LeaseSec  Type           VLAN  Interface
+//	-----------------  ---------------  --------  -------------  ----  -------------
+//	00:50:56:a1:2c:01  10.10.10.21      86011     dhcp-snoop     10    Ethernet1/5
+func parseDHCPSnoopingBindings(s string) ([]*DHCPSnoopingBinding, error) {
+	bindings := []*DHCPSnoopingBinding{}
+	header := false
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "---") {
+			continue
+		}
+		if fields[0] == "MacAddress" {
+			header = true
+			continue
+		}
+		if !header {
+			continue
+		}
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("malformed dhcp snooping binding: %s", line)
+		}
+		b := &DHCPSnoopingBinding{
+			MacAddress: fields[0],
+			IPAddress:  fields[1],
+			Lease:      -1,
+			Type:       fields[3],
+			Interface:  fields[5],
+		}
+		if lease, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			b.Lease = time.Duration(lease) * time.Second
+		}
+		vlan, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("malformed dhcp snooping binding vlan: %s", line)
+		}
+		b.Vlan = vlan
+		bindings = append(bindings, b)
+	}
+	if !header {
+		return nil, fmt.Errorf("no dhcp snooping bindings found: %s", s)
+	}
+	return bindings, nil
+}
+
+// NewDHCPRelayStatisticsFromString returns DHCPRelayStatistics instance
+// from an input string.
+func NewDHCPRelayStatisticsFromString(s string) (*DHCPRelayStatistics, error) {
+	return NewDHCPRelayStatisticsFromBytes([]byte(s))
+}
+
+// NewDHCPRelayStatisticsFromBytes returns DHCPRelayStatistics instance
+// from an input byte array.
+func NewDHCPRelayStatisticsFromBytes(s []byte) (*DHCPRelayStatistics, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseDHCPRelayStatistics(resp.Result.Outputs.Output.Body)
+}
+
+// parseDHCPRelayStatistics parses the text output of "show ip dhcp relay
+// statistics", e.g.
+//
+//	Message Type             Rx              Tx           Drops
+//	----------------------------------------------------------------------
+//	Discover                  5               5               0
+//	Request(*)                5               5               0
+//	----------------------------------------------------------------------
+//	Total                    10              10               0
+//
+//	DROP:
+//	DHCP Relay not enabled                           :         0
+func parseDHCPRelayStatistics(s string) (*DHCPRelayStatistics, error) {
+	stats := &DHCPRelayStatistics{
+		Messages: []DHCPRelayMessageCounters{},
+		Drops:    make(map[string]uint64),
+	}
+	inMessages, inDrops, found := false, false, false
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "---"):
+			continue
+		case strings.HasPrefix(line, "Message Type"):
+			inMessages, inDrops, found = true, false, true
+			continue
+		case line == "DROP:":
+			inMessages, inDrops = false, true
+			continue
+		case strings.HasSuffix(line, ":"):
+			inMessages, inDrops = false, false
+			continue
+		}
+		if inDrops {
+			i := strings.LastIndex(line, ":")
+			if i < 0 {
+				continue
+			}
+			v, err := strconv.ParseUint(strings.TrimSpace(line[i+1:]), 10, 64)
+			if err != nil {
+				continue
+			}
+			stats.Drops[strings.TrimSpace(line[:i])] = v
+			continue
+		}
+		if !inMessages {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("malformed dhcp relay message counters: %s", line)
+		}
+		counters := DHCPRelayMessageCounters{
+			Type: strings.TrimSuffix(fields[0], "(*)"),
+		}
+		var err error
+		if counters.Received, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+			return nil, fmt.Errorf("malformed dhcp relay message counters: %s", line)
+		}
+		if counters.Sent, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+			return nil, fmt.Errorf("malformed dhcp relay message counters: %s", line)
+		}
+		if counters.Dropped, err = strconv.ParseUint(fields[3], 10, 64); err != nil {
+			return nil, fmt.Errorf("malformed dhcp relay message counters: %s", line)
+		}
+		if counters.Type == "Total" {
+			stats.Total = counters
+			inMessages = false
+			continue
+		}
+		stats.Messages = append(stats.Messages, counters)
+	}
+	if !found {
+		return nil, fmt.Errorf("no dhcp relay statistics found: %s", s)
+	}
+	return stats, nil
+}
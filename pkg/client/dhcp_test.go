@@ -0,0 +1,125 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShowIPDHCPSnoopingBindingOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *DHCPSnoopingBinding
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.ip.dhcp.snooping.binding.1",
+			exp: &DHCPSnoopingBinding{
+				MacAddress: "00:50:56:a1:2c:01",
+				IPAddress:  "10.10.10.21",
+				Lease:      86011 * time.Second,
+				Type:       "dhcp-snoop",
+				Vlan:       10,
+				Interface:  "Ethernet1/5",
+			},
+			count:      3,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "show.ip.dhcp.relay.statistics.1",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewDHCPSnoopingBindingsFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestParseShowIPDHCPRelayStatisticsOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.show.ip.dhcp.relay.statistics.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	stats, err := NewDHCPRelayStatisticsFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if len(stats.Messages) != 8 {
+		t.Fatalf("unexpected number of message types: %d", len(stats.Messages))
+	}
+	exp := DHCPRelayMessageCounters{Type: "Request", Received: 40, Sent: 40}
+	if !reflect.DeepEqual(exp, stats.Messages[2]) {
+		t.Fatalf("unexpected message counters: %v", stats.Messages[2])
+	}
+	exp = DHCPRelayMessageCounters{Type: "Total", Received: 164, Sent: 164}
+	if !reflect.DeepEqual(exp, stats.Total) {
+		t.Fatalf("unexpected total counters: %v", stats.Total)
+	}
+	if len(stats.Drops) != 11 || stats.Drops["Interface error"] != 3 {
+		t.Fatalf("unexpected drop counters: %v", stats.Drops)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errDisableRecoveryIntervalRegex = regexp.MustCompile(`^Timer interval:\s*(\d+)`)
+
+// ErrDisabledInterface is an interface in err-disabled state. The
+// information in the structure is from the output of "show interface status
+// err-disabled" and "show errdisable recovery" commands. The Cause is the
+// errdisable cause matching the Reason, e.g. "bpduguard" or "link-flap".
+type ErrDisabledInterface struct {
+	Interface        string        `json:"interface" xml:"interface"`
+	Name             string        `json:"name" xml:"name"`
+	Reason           string        `json:"reason" xml:"reason"`
+	Cause            string        `json:"cause" xml:"cause"`
+	RecoveryEnabled  bool          `json:"recovery_enabled" xml:"recovery_enabled"`
+	RecoveryInterval time.Duration `json:"recovery_interval" xml:"recovery_interval"`
+}
+
+// ErrDisableRecovery contains errdisable recovery configuration. The
+// information in the structure is from the output of "show errdisable
+// recovery" command.
+type ErrDisableRecovery struct {
+	Interval time.Duration   `json:"interval" xml:"interval"`
+	Causes   map[string]bool `json:"causes" xml:"causes"`
+}
+
+// NewErrDisabledInterfacesFromString returns ErrDisabledInterface instances
+// from an input string.
+func NewErrDisabledInterfacesFromString(s string) ([]*ErrDisabledInterface, error) {
+	return NewErrDisabledInterfacesFromBytes([]byte(s))
+}
+
+// NewErrDisabledInterfacesFromBytes returns ErrDisabledInterface instances
+// from an input byte array. The recovery information is not populated.
+func NewErrDisabledInterfacesFromBytes(s []byte) ([]*ErrDisabledInterface, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseErrDisabledInterfaces(resp.Result.Outputs.Output.Body)
+}
+
+// NewErrDisableRecoveryFromString returns ErrDisableRecovery instance from
+// an input string.
+func NewErrDisableRecoveryFromString(s string) (*ErrDisableRecovery, error) {
+	return NewErrDisableRecoveryFromBytes([]byte(s))
+}
+
+// NewErrDisableRecoveryFromBytes returns ErrDisableRecovery instance from
+// an input byte array.
+func NewErrDisableRecoveryFromBytes(s []byte) (*ErrDisableRecovery, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseErrDisableRecovery(resp.Result.Outputs.Output.Body)
+}
+
+// Apply sets the recovery information and the cause of an err-disabled
+// interface.
+func (r *ErrDisableRecovery) Apply(intf *ErrDisabledInterface) {
+	reason := normalizeErrDisableCause(intf.Reason)
+	for cause, enabled := range r.Causes {
+		if normalizeErrDisableCause(cause) != reason {
+			continue
+		}
+		intf.Cause = cause
+		intf.RecoveryEnabled = enabled
+		if enabled {
+			intf.RecoveryInterval = r.Interval
+		}
+		return
+	}
+}
+
+// normalizeErrDisableCause returns a comparable form of errdisable causes
+// and reasons, e.g. "link-flap" and "linkFlapErrDisabled" are "linkflap".
+func normalizeErrDisableCause(s string) string {
+	s = strings.ToLower(s)
+	for _, suffix := range []string{"errdisabled", "errdisable"} {
+		s = strings.TrimSpace(strings.TrimSuffix(s, suffix))
+	}
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(s)
+}
+
+// parseErrDisabledInterfaces parses the text output of "show interface
+// status err-disabled", e.g.
+//
+//	--------------------------------------------------------------------------------
+//	Port           Name               Status   Reason
+//	--------------------------------------------------------------------------------
+//	Eth1/10        --                 down     BPDUGuard errDisable
+//
+// The columns are located by the header, because the name of an interface
+// may contain spaces.
+func parseErrDisabledInterfaces(s string) ([]*ErrDisabledInterface, error) {
+	interfaces := []*ErrDisabledInterface{}
+	var nameCol, statusCol, reasonCol int
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if strings.HasPrefix(line, "Port ") {
+			nameCol = strings.Index(line, "Name")
+			statusCol = strings.Index(line, "Status")
+			reasonCol = strings.Index(line, "Reason")
+			if nameCol < 0 || statusCol < nameCol || reasonCol < statusCol {
+				return nil, fmt.Errorf("malformed header: %s", line)
+			}
+			continue
+		}
+		if reasonCol == 0 || line == "" || strings.HasPrefix(line, "---") {
+			continue
+		}
+		if len(line) <= reasonCol {
+			return nil, fmt.Errorf("malformed err-disabled entry: %s", line)
+		}
+		intf := &ErrDisabledInterface{
+			Interface: strings.TrimSpace(line[:nameCol]),
+			Name:      strings.TrimSpace(line[nameCol:statusCol]),
+			Reason:    strings.TrimSpace(line[reasonCol:]),
+		}
+		if intf.Name == "--" {
+			intf.Name = ""
+		}
+		interfaces = append(interfaces, intf)
+	}
+	return interfaces, nil
+}
+
+// parseErrDisableRecovery parses the text output of "show errdisable
+// recovery", e.g.
+//
+//	ErrDisable Reason               Timer Status
+//	-----------------               ------------
+//	link-flap                       enabled
+//	bpduguard                       disabled
+//
+//	        Timer interval: 300
+func parseErrDisableRecovery(s string) (*ErrDisableRecovery, error) {
+	r := &ErrDisableRecovery{
+		Causes: make(map[string]bool),
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if m := errDisableRecoveryIntervalRegex.FindStringSubmatch(line); m != nil {
+			interval, _ := strconv.Atoi(m[1])
+			r.Interval = time.Duration(interval) * time.Second
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[1] {
+		case "enabled":
+			r.Causes[fields[0]] = true
+		case "disabled":
+			r.Causes[fields[0]] = false
+		}
+	}
+	if len(r.Causes) == 0 {
+		return nil, fmt.Errorf("no errdisable causes found: %s", s)
+	}
+	return r, nil
+}
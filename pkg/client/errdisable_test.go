@@ -0,0 +1,146 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShowInterfaceStatusErrDisabledOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *ErrDisabledInterface
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.interface.status.err-disabled.1",
+			exp: &ErrDisabledInterface{
+				Interface: "Eth1/10",
+				Reason:    "BPDUGuard errDisable",
+			},
+			count:      3,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewErrDisabledInterfacesFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestErrDisableRecoveryApply(t *testing.T) {
+	outputDir := "../../assets/requests"
+	fp := fmt.Sprintf("%s/resp.%s.json", outputDir, "show.interface.status.err-disabled.1")
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	interfaces, err := NewErrDisabledInterfacesFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	fp = fmt.Sprintf("%s/resp.%s.json", outputDir, "show.errdisable.recovery.1")
+	content, err = ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	recovery, err := NewErrDisableRecoveryFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if recovery.Interval != 300*time.Second || len(recovery.Causes) != 6 {
+		t.Fatalf("unexpected errdisable recovery: %v", recovery)
+	}
+	for _, intf := range interfaces {
+		recovery.Apply(intf)
+	}
+	exp := []*ErrDisabledInterface{
+		{
+			Interface:        "Eth1/10",
+			Reason:           "BPDUGuard errDisable",
+			Cause:            "bpduguard",
+			RecoveryEnabled:  true,
+			RecoveryInterval: 300 * time.Second,
+		},
+		{
+			Interface:        "Eth1/11",
+			Name:             "server 11 uplink",
+			Reason:           "link-flap errDisable",
+			Cause:            "link-flap",
+			RecoveryEnabled:  true,
+			RecoveryInterval: 300 * time.Second,
+		},
+		{
+			Interface: "Eth1/12",
+			Reason:    "udldErrDisabled",
+			Cause:     "udld",
+		},
+	}
+	if !reflect.DeepEqual(exp, interfaces) {
+		for _, intf := range interfaces {
+			t.Logf("%v", intf)
+		}
+		t.Fatalf("unexpected err-disabled interfaces")
+	}
+}
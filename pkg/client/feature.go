@@ -0,0 +1,99 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+)
+
+type featureResponse struct {
+	ID      uint64                `json:"id" xml:"id"`
+	Version string                `json:"jsonrpc" xml:"jsonrpc"`
+	Result  featureResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError `json:"error,omitempty" xml:"error"`
+}
+
+type featureResponseResult struct {
+	Body featureResponseResultBody `json:"body" xml:"body"`
+}
+
+type featureResponseResultBody struct {
+	FeatureTable []struct {
+		FeatureRow []struct {
+			Name     string `json:"cfcFeatureCtrlName2" xml:"cfcFeatureCtrlName2"`
+			Instance int    `json:"cfcFeatureCtrlInstanceNum2" xml:"cfcFeatureCtrlInstanceNum2"`
+			Status   string `json:"cfcFeatureCtrlOpStatus2" xml:"cfcFeatureCtrlOpStatus2"`
+		} `json:"ROW_cfcFeatureCtrlTable" xml:"ROW_cfcFeatureCtrlTable"`
+	} `json:"TABLE_cfcFeatureCtrlTable" xml:"TABLE_cfcFeatureCtrlTable"`
+}
+
+// Feature contains the state of a feature instance. The information in
+// the structure is from the output of "show feature" command. A feature
+// is enabled, but not running, when its status is "enabled(not-running)".
+type Feature struct {
+	Name     string `json:"name" xml:"name"`
+	Instance int    `json:"instance" xml:"instance"`
+	Status   string `json:"status" xml:"status"`
+	Enabled  bool   `json:"enabled" xml:"enabled"`
+	Running  bool   `json:"running" xml:"running"`
+}
+
+// FeatureEnabled returns true when any instance of the named feature,
+// e.g. "bgp" or "nve", is enabled.
+func FeatureEnabled(features []*Feature, name string) bool {
+	for _, f := range features {
+		if f.Name == name && f.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// NewFeaturesFromString returns Feature instances from an input string.
+func NewFeaturesFromString(s string) ([]*Feature, error) {
+	return NewFeaturesFromBytes([]byte(s))
+}
+
+// NewFeaturesFromBytes returns Feature instances from an input byte array.
+func NewFeaturesFromBytes(s []byte) ([]*Feature, error) {
+	var features []*Feature
+	resp := &featureResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.FeatureTable {
+		for _, r := range t.FeatureRow {
+			status := strings.TrimSpace(r.Status)
+			features = append(features, &Feature{
+				Name:     r.Name,
+				Instance: r.Instance,
+				Status:   status,
+				Enabled:  strings.HasPrefix(status, "enabled"),
+				Running:  status == "enabled",
+			})
+		}
+	}
+	return features, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dirTimestampFormat = "Jan _2 15:04:05 2006"
+
+var (
+	dirEntryRegex     = regexp.MustCompile(`^\s*(\d+)\s+(\w{3}\s+\d{1,2} \d{2}:\d{2}:\d{2} \d{4})\s+(.+)$`)
+	dirUsageRegex     = regexp.MustCompile(`^Usage for (\S+)`)
+	dirTotalsRegex    = regexp.MustCompile(`^\s*(\d+) bytes (used|free|total)`)
+	fileChecksumRegex = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+)
+
+// DirEntry is a file or a directory of DirListing.
+type DirEntry struct {
+	Name    string    `json:"name" xml:"name"`
+	Size    uint64    `json:"size" xml:"size"`
+	ModTime time.Time `json:"mod_time" xml:"mod_time"`
+	IsDir   bool      `json:"is_dir" xml:"is_dir"`
+}
+
+// DirListing contains the content of a directory and the usage of its
+// filesystem. The information in the structure is from the output of
+// "dir" command.
+type DirListing struct {
+	Filesystem string      `json:"filesystem" xml:"filesystem"`
+	Entries    []*DirEntry `json:"entries" xml:"entries"`
+	Used       uint64      `json:"used" xml:"used"`
+	Free       uint64      `json:"free" xml:"free"`
+	Total      uint64      `json:"total" xml:"total"`
+}
+
+// Get returns the entry with a particular name, or nil when there is none.
+func (d *DirListing) Get(name string) *DirEntry {
+	for _, e := range d.Entries {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// NewDirListingFromString returns DirListing instance from an input string.
+func NewDirListingFromString(s string) (*DirListing, error) {
+	return NewDirListingFromBytes([]byte(s))
+}
+
+// NewDirListingFromBytes returns DirListing instance from an input byte
+// array. The modification times are interpreted as UTC.
+func NewDirListingFromBytes(s []byte) (*DirListing, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseDirListing(resp.Result.Outputs.Output.Body)
+}
+
+// parseDirListing parses the text output of "dir", e.g.
+//
+//	  982045184    Nov 14 11:20:01 2018  nxos.7.0.3.I7.5a.bin
+//	       4096    Jan 09 10:05:26 2018  scripts/
+//
+//	Usage for bootflash://sup-local
+//	 2389311488 bytes used
+//	51285278720 bytes free
+//	53674590208 bytes total
+func parseDirListing(s string) (*DirListing, error) {
+	d := &DirListing{
+		Entries: []*DirEntry{},
+	}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if m := dirEntryRegex.FindStringSubmatch(line); m != nil {
+			e := &DirEntry{Name: m[3]}
+			e.Size, _ = strconv.ParseUint(m[1], 10, 64)
+			ts, err := time.Parse(dirTimestampFormat, strings.Join(strings.Fields(m[2]), " "))
+			if err != nil {
+				return nil, fmt.Errorf("malformed dir entry: %s", line)
+			}
+			e.ModTime = ts
+			if strings.HasSuffix(e.Name, "/") {
+				e.Name = strings.TrimSuffix(e.Name, "/")
+				e.IsDir = true
+			}
+			d.Entries = append(d.Entries, e)
+			continue
+		}
+		if m := dirUsageRegex.FindStringSubmatch(line); m != nil {
+			d.Filesystem = m[1]
+			continue
+		}
+		if m := dirTotalsRegex.FindStringSubmatch(line); m != nil {
+			v, _ := strconv.ParseUint(m[1], 10, 64)
+			switch m[2] {
+			case "used":
+				d.Used = v
+			case "free":
+				d.Free = v
+			case "total":
+				d.Total = v
+			}
+		}
+	}
+	if d.Filesystem == "" {
+		return nil, fmt.Errorf("no filesystem usage found: %s", s)
+	}
+	return d, nil
+}
+
+// NewFileChecksumFromString returns a file checksum from an input string.
+func NewFileChecksumFromString(s string) (string, error) {
+	return NewFileChecksumFromBytes([]byte(s))
+}
+
+// NewFileChecksumFromBytes returns a file checksum from an input byte
+// array. The information is from the output of "show file <path> md5sum"
+// or "show file <path> sha256sum" command.
+func NewFileChecksumFromBytes(s []byte) (string, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return "", fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return "", fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	checksum := strings.TrimSpace(resp.Result.Outputs.Output.Body)
+	if !fileChecksumRegex.MatchString(checksum) {
+		return "", fmt.Errorf("malformed checksum: %s", checksum)
+	}
+	return strings.ToLower(checksum), nil
+}
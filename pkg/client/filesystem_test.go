@@ -0,0 +1,109 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDirOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.dir.bootflash.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	d, err := NewDirListingFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if d.Filesystem != "bootflash://sup-local" || d.Used != 2389311488 || d.Free != 51285278720 || d.Total != 53674590208 {
+		t.Fatalf("unexpected filesystem usage: %v", d)
+	}
+	if len(d.Entries) != 5 {
+		t.Fatalf("unexpected number of entries: %d", len(d.Entries))
+	}
+	exp := &DirEntry{
+		Name:    "nxos.7.0.3.I7.5a.bin",
+		Size:    982045184,
+		ModTime: time.Date(2018, time.November, 14, 11, 20, 1, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(exp, d.Get("nxos.7.0.3.I7.5a.bin")) {
+		t.Fatalf("unexpected entry: %v", d.Get("nxos.7.0.3.I7.5a.bin"))
+	}
+	if e := d.Get("scripts"); e == nil || !e.IsDir || e.ModTime.Day() != 9 {
+		t.Fatalf("unexpected directory entry: %v", e)
+	}
+	if d.Get("missing.bin") != nil {
+		t.Fatalf("unexpected entry for missing file")
+	}
+}
+
+func TestParseShowFileChecksumOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input     string
+		exp       string
+		shouldErr bool
+	}{
+		{
+			input: "show.file.md5sum.1",
+			exp:   "e8f0a9b0c1c8a3d57d2d8e4b1f1d7e52",
+		},
+		{
+			input: "show.file.sha256sum.1",
+			exp:   "3a7bd3e2360a3d29eea436fcfb7e44c735d117c42d1c1835420b6b9942dd4f1b",
+		},
+		{
+			input:     "dir.bootflash.1",
+			shouldErr: true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		checksum, err := NewFileChecksumFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, checksum)
+				testFailed++
+				continue
+			}
+		}
+		if checksum != test.exp {
+			t.Logf("FAIL: Test %d: input '%s', expected %s, got %s", i, test.input, test.exp, checksum)
+			testFailed++
+			continue
+		}
+		t.Logf("PASS: Test %d: input '%s'", i, test.input)
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
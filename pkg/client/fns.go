@@ -16,9 +16,12 @@ package client
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 func StrInt(s string) int {
@@ -198,3 +201,60 @@ func ParseDuration(s string) time.Duration {
 	}
 	return time.Duration(d) //, nil
 }
+
+// parseVlanRange parses a list of VLANs, e.g. "1,10-12" is 1, 10, 11 and 12.
+func parseVlanRange(s string) ([]int, error) {
+	var vlans []int
+	seen := make(map[int]bool)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		bounds := strings.SplitN(item, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid vlan range: %s", s)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("invalid vlan range: %s", s)
+			}
+		}
+		if first < 1 || last > 4094 || first > last {
+			return nil, fmt.Errorf("invalid vlan range: %s", s)
+		}
+		for vlan := first; vlan <= last; vlan++ {
+			if !seen[vlan] {
+				seen[vlan] = true
+				vlans = append(vlans, vlan)
+			}
+		}
+	}
+	sort.Ints(vlans)
+	return vlans, nil
+}
+
+// formatVlanRange returns the shortest list of sorted VLANs, e.g. 1, 10, 11
+// and 12 are "1,10-12".
+func formatVlanRange(vlans []int) string {
+	var items []string
+	for i := 0; i < len(vlans); i++ {
+		j := i
+		for j+1 < len(vlans) && vlans[j+1] == vlans[j]+1 {
+			j++
+		}
+		switch {
+		case j == i:
+			items = append(items, strconv.Itoa(vlans[i]))
+		default:
+			items = append(items, fmt.Sprintf("%d-%d", vlans[i], vlans[j]))
+		}
+		i = j
+	}
+	return strings.Join(items, ",")
+}
+
+// hasControlChars returns true when a string contains control characters,
+// e.g. a newline splitting a configuration command in two.
+func hasControlChars(s string) bool {
+	return strings.IndexFunc(s, unicode.IsControl) >= 0
+}
@@ -0,0 +1,199 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hardwareCapacityModuleRegex = regexp.MustCompile(`\((?:Mod|Module) (\d+)\)|^Module (\d+)$`)
+
+// TCAMRegion is the utilization of a TCAM region. The information in the
+// structure is from the output of "show hardware access-list resource
+// utilization" command.
+type TCAMRegion struct {
+	Module  int     `json:"module" xml:"module"`
+	Name    string  `json:"name" xml:"name"`
+	Used    uint64  `json:"used" xml:"used"`
+	Free    uint64  `json:"free" xml:"free"`
+	Percent float64 `json:"percent" xml:"percent"`
+}
+
+// ForwardingTable is the utilization of a forwarding table, e.g. IPv4
+// routes, MAC addresses or ARP/ND adjacencies. The information in the
+// structure is from the output of "show system internal forwarding table
+// utilization" command.
+type ForwardingTable struct {
+	Module  int     `json:"module" xml:"module"`
+	Name    string  `json:"name" xml:"name"`
+	Used    uint64  `json:"used" xml:"used"`
+	Max     uint64  `json:"max" xml:"max"`
+	Percent float64 `json:"percent" xml:"percent"`
+}
+
+// HardwareCapacity contains the utilization of hardware resources.
+type HardwareCapacity struct {
+	TCAMRegions      []*TCAMRegion      `json:"tcam_regions" xml:"tcam_regions"`
+	ForwardingTables []*ForwardingTable `json:"forwarding_tables" xml:"forwarding_tables"`
+}
+
+// TCAMRegionsAbove returns the TCAM regions with utilization above the
+// percentage threshold.
+func (c *HardwareCapacity) TCAMRegionsAbove(threshold float64) []*TCAMRegion {
+	var regions []*TCAMRegion
+	for _, r := range c.TCAMRegions {
+		if r.Percent > threshold {
+			regions = append(regions, r)
+		}
+	}
+	return regions
+}
+
+// ForwardingTablesAbove returns the forwarding tables with utilization
+// above the percentage threshold.
+func (c *HardwareCapacity) ForwardingTablesAbove(threshold float64) []*ForwardingTable {
+	var tables []*ForwardingTable
+	for _, t := range c.ForwardingTables {
+		if t.Percent > threshold {
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+// NewTCAMRegionsFromString returns TCAMRegion instances from an input string.
+func NewTCAMRegionsFromString(s string) ([]*TCAMRegion, error) {
+	return NewTCAMRegionsFromBytes([]byte(s))
+}
+
+// NewTCAMRegionsFromBytes returns TCAMRegion instances from an input byte
+// array.
+func NewTCAMRegionsFromBytes(s []byte) ([]*TCAMRegion, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	body := resp.Result.Outputs.Output.Body
+	var regions []*TCAMRegion
+	for _, row := range parseHardwareCapacityRows(body) {
+		regions = append(regions, &TCAMRegion{
+			Module:  row.module,
+			Name:    row.name,
+			Used:    row.values[0],
+			Free:    row.values[1],
+			Percent: row.percent,
+		})
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("no tcam regions found: %s", body)
+	}
+	return regions, nil
+}
+
+// NewForwardingTablesFromString returns ForwardingTable instances from an
+// input string.
+func NewForwardingTablesFromString(s string) ([]*ForwardingTable, error) {
+	return NewForwardingTablesFromBytes([]byte(s))
+}
+
+// NewForwardingTablesFromBytes returns ForwardingTable instances from an
+// input byte array.
+func NewForwardingTablesFromBytes(s []byte) ([]*ForwardingTable, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	body := resp.Result.Outputs.Output.Body
+	var tables []*ForwardingTable
+	for _, row := range parseHardwareCapacityRows(body) {
+		tables = append(tables, &ForwardingTable{
+			Module:  row.module,
+			Name:    row.name,
+			Used:    row.values[0],
+			Max:     row.values[1],
+			Percent: row.percent,
+		})
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no forwarding tables found: %s", body)
+	}
+	return tables, nil
+}
+
+type hardwareCapacityRow struct {
+	module  int
+	name    string
+	values  [2]uint64
+	percent float64
+}
+
+// parseHardwareCapacityRows parses the rows of the utilization tables,
+// e.g.
+//
+//	ACL Hardware Resource Utilization (Mod 1)
+//	--------------------------------------------
+//	                              Used    Free    Percent
+//	                                              Utilization
+//	-----------------------------------------------------
+//	Ingress IPv4 PACL              2       254     0.78
+//
+// Each row ends with two counters and the utilization percentage, and the
+// module is taken from the preceding table header.
+func parseHardwareCapacityRows(s string) []hardwareCapacityRow {
+	var rows []hardwareCapacityRow
+	module := 0
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if m := hardwareCapacityModuleRegex.FindStringSubmatch(line); m != nil {
+			module, _ = strconv.Atoi(m[1] + m[2])
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		n := len(fields)
+		row := hardwareCapacityRow{
+			module: module,
+			name:   strings.Join(fields[:n-3], " "),
+		}
+		var err error
+		if row.values[0], err = strconv.ParseUint(fields[n-3], 10, 64); err != nil {
+			continue
+		}
+		if row.values[1], err = strconv.ParseUint(fields[n-2], 10, 64); err != nil {
+			continue
+		}
+		if row.percent, err = strconv.ParseFloat(strings.TrimSuffix(fields[n-1], "%"), 64); err != nil {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestParseShowHardwareAccessListResourceUtilizationOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *TCAMRegion
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.hardware.access-list.resource.utilization.1",
+			exp: &TCAMRegion{
+				Module:  1,
+				Name:    "Ingress IPv4 PACL",
+				Used:    2,
+				Free:    254,
+				Percent: 0.78,
+			},
+			count:      8,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewTCAMRegionsFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestParseShowSystemInternalForwardingTableUtilizationOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *ForwardingTable
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.system.internal.forwarding.table.utilization.1",
+			exp: &ForwardingTable{
+				Module:  1,
+				Name:    "IPv4 LPM Routes",
+				Used:    1200,
+				Max:     901120,
+				Percent: 0.13,
+			},
+			count:      6,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewForwardingTablesFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestHardwareCapacityThreshold(t *testing.T) {
+	var content [2][]byte
+	for i, f := range []string{
+		"show.hardware.access-list.resource.utilization.1",
+		"show.system.internal.forwarding.table.utilization.1",
+	} {
+		fp := fmt.Sprintf("../../assets/requests/resp.%s.json", f)
+		b, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Fatalf("failed reading '%s', error: %v", fp, err)
+		}
+		content[i] = b
+	}
+	regions, err := NewTCAMRegionsFromBytes(content[0])
+	if err != nil {
+		t.Fatalf("failed parsing TCAM regions: %v", err)
+	}
+	tables, err := NewForwardingTablesFromBytes(content[1])
+	if err != nil {
+		t.Fatalf("failed parsing forwarding tables: %v", err)
+	}
+	capacity := &HardwareCapacity{
+		TCAMRegions:      regions,
+		ForwardingTables: tables,
+	}
+	regions = capacity.TCAMRegionsAbove(80)
+	if len(regions) != 1 || regions[0].Name != "Ingress IPv4 RACL" {
+		t.Fatalf("unexpected TCAM regions above threshold: %v", regions)
+	}
+	tables = capacity.ForwardingTablesAbove(80)
+	if len(tables) != 1 || tables[0].Name != "MAC Addresses" {
+		t.Fatalf("unexpected forwarding tables above threshold: %v", tables)
+	}
+}
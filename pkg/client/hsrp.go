@@ -0,0 +1,150 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+	"time"
+)
+
+type hsrpResponse struct {
+	ID      uint64                `json:"id" xml:"id"`
+	Version string                `json:"jsonrpc" xml:"jsonrpc"`
+	Result  hsrpResponseResult    `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError `json:"error,omitempty" xml:"error"`
+}
+
+type hsrpResponseResult struct {
+	Body hsrpResponseResultBody `json:"body" xml:"body"`
+}
+
+type hsrpResponseResultBody struct {
+	GroupTable []struct {
+		GroupRow []hsrpResponseResultBodyGroupRow `json:"ROW_grp_detail" xml:"ROW_grp_detail"`
+	} `json:"TABLE_grp_detail" xml:"TABLE_grp_detail"`
+}
+
+type hsrpResponseResultBodyGroupRow struct {
+	Interface             string `json:"sh_if_index" xml:"sh_if_index"`
+	Group                 int    `json:"sh_group_num" xml:"sh_group_num"`
+	AddressFamily         string `json:"sh_group_type" xml:"sh_group_type"`
+	Version               string `json:"sh_group_version" xml:"sh_group_version"`
+	State                 string `json:"sh_group_state" xml:"sh_group_state"`
+	Priority              int    `json:"sh_prio" xml:"sh_prio"`
+	ConfiguredPriority    int    `json:"sh_cfg_prio" xml:"sh_cfg_prio"`
+	Preempt               string `json:"sh_preempt" xml:"sh_preempt"`
+	HelloTime             int    `json:"sh_cur_hello" xml:"sh_cur_hello"`
+	HelloTimeUnit         string `json:"sh_cur_hello_attr" xml:"sh_cur_hello_attr"`
+	HoldTime              int    `json:"sh_cur_hold" xml:"sh_cur_hold"`
+	HoldTimeUnit          string `json:"sh_cur_hold_attr" xml:"sh_cur_hold_attr"`
+	VirtualIP             string `json:"sh_vip" xml:"sh_vip"`
+	VirtualMAC            string `json:"sh_vmac" xml:"sh_vmac"`
+	ActiveRouter          string `json:"sh_active_router_addr" xml:"sh_active_router_addr"`
+	ActiveRouterPriority  int    `json:"sh_active_router_prio" xml:"sh_active_router_prio"`
+	StandbyRouter         string `json:"sh_standby_router_addr" xml:"sh_standby_router_addr"`
+	StandbyRouterPriority int    `json:"sh_standby_router_prio" xml:"sh_standby_router_prio"`
+	StateChanges          int    `json:"sh_num_of_state_changes" xml:"sh_num_of_state_changes"`
+	LastStateChange       int    `json:"sh_last_state_change" xml:"sh_last_state_change"`
+}
+
+// HSRPGroup contains HSRP group information. The information in the
+// structure is from the output of "show hsrp detail" command.
+type HSRPGroup struct {
+	Interface             string        `json:"interface" xml:"interface"`
+	Group                 int           `json:"group" xml:"group"`
+	AddressFamily         string        `json:"address_family" xml:"address_family"`
+	Version               string        `json:"version" xml:"version"`
+	State                 string        `json:"state" xml:"state"`
+	Priority              int           `json:"priority" xml:"priority"`
+	ConfiguredPriority    int           `json:"configured_priority" xml:"configured_priority"`
+	Preempt               bool          `json:"preempt" xml:"preempt"`
+	HelloTime             time.Duration `json:"hello_time" xml:"hello_time"`
+	HoldTime              time.Duration `json:"hold_time" xml:"hold_time"`
+	VirtualIP             string        `json:"virtual_ip" xml:"virtual_ip"`
+	VirtualMAC            string        `json:"virtual_mac" xml:"virtual_mac"`
+	ActiveRouter          string        `json:"active_router" xml:"active_router"`
+	ActiveRouterPriority  int           `json:"active_router_priority" xml:"active_router_priority"`
+	StandbyRouter         string        `json:"standby_router" xml:"standby_router"`
+	StandbyRouterPriority int           `json:"standby_router_priority" xml:"standby_router_priority"`
+	StateChanges          int           `json:"state_changes" xml:"state_changes"`
+	LastStateChange       time.Duration `json:"last_state_change" xml:"last_state_change"`
+}
+
+// IsActive returns true when the local router is the active router
+// for the group.
+func (g *HSRPGroup) IsActive() bool {
+	return strings.EqualFold(g.State, "active")
+}
+
+// fhrpTimer converts a first-hop redundancy protocol timer value and its
+// unit, i.e. "sec" or "msec", to time.Duration.
+func fhrpTimer(v int, unit string) time.Duration {
+	if strings.HasPrefix(strings.ToLower(unit), "msec") {
+		return time.Duration(v) * time.Millisecond
+	}
+	return time.Duration(v) * time.Second
+}
+
+// NewHSRPGroupsFromString returns HSRPGroup instances from an input string.
+func NewHSRPGroupsFromString(s string) ([]*HSRPGroup, error) {
+	return NewHSRPGroupsFromBytes([]byte(s))
+}
+
+// NewHSRPGroupsFromBytes returns HSRPGroup instances from an input byte array.
+func NewHSRPGroupsFromBytes(s []byte) ([]*HSRPGroup, error) {
+	var groups []*HSRPGroup
+	resp := &hsrpResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.GroupTable {
+		for _, r := range t.GroupRow {
+			g := &HSRPGroup{
+				Interface:             r.Interface,
+				Group:                 r.Group,
+				AddressFamily:         r.AddressFamily,
+				Version:               r.Version,
+				State:                 r.State,
+				Priority:              r.Priority,
+				ConfiguredPriority:    r.ConfiguredPriority,
+				HelloTime:             fhrpTimer(r.HelloTime, r.HelloTimeUnit),
+				HoldTime:              fhrpTimer(r.HoldTime, r.HoldTimeUnit),
+				VirtualIP:             r.VirtualIP,
+				VirtualMAC:            r.VirtualMAC,
+				ActiveRouter:          r.ActiveRouter,
+				ActiveRouterPriority:  r.ActiveRouterPriority,
+				StandbyRouter:         r.StandbyRouter,
+				StandbyRouterPriority: r.StandbyRouterPriority,
+				StateChanges:          r.StateChanges,
+				LastStateChange:       time.Duration(r.LastStateChange) * time.Second,
+			}
+			if strings.EqualFold(r.Preempt, "enabled") {
+				g.Preempt = true
+			}
+			groups = append(groups, g)
+		}
+	}
+	return groups, nil
+}
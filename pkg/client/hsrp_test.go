@@ -0,0 +1,132 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShowHSRPDetailJsonOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *HSRPGroup
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.hsrp.detail.1",
+			exp: &HSRPGroup{
+				Interface:             "Vlan100",
+				Group:                 100,
+				AddressFamily:         "v4",
+				Version:               "v2",
+				State:                 "Active",
+				Priority:              110,
+				ConfiguredPriority:    110,
+				Preempt:               true,
+				HelloTime:             time.Second,
+				HoldTime:              3 * time.Second,
+				VirtualIP:             "10.1.100.1",
+				VirtualMAC:            "0000.0c9f.f064",
+				ActiveRouter:          "local",
+				ActiveRouterPriority:  110,
+				StandbyRouter:         "10.1.100.3",
+				StandbyRouterPriority: 100,
+				StateChanges:          2,
+				LastStateChange:       336 * time.Hour,
+			},
+			count:      2,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input: "show.hsrp.detail.2",
+			exp: &HSRPGroup{
+				Interface:             "Eth1/3",
+				Group:                 0,
+				AddressFamily:         "v4",
+				Version:               "v1",
+				State:                 "Listen",
+				Priority:              90,
+				ConfiguredPriority:    90,
+				Preempt:               true,
+				HelloTime:             3 * time.Second,
+				HoldTime:              10 * time.Second,
+				VirtualIP:             "192.168.1.254",
+				VirtualMAC:            "0000.0c07.ac00",
+				ActiveRouter:          "192.168.1.1",
+				ActiveRouterPriority:  120,
+				StandbyRouter:         "192.168.1.2",
+				StandbyRouterPriority: 110,
+				StateChanges:          1,
+				LastStateChange:       time.Hour,
+			},
+			count:      1,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		groups, err := NewHSRPGroupsFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, groups)
+				testFailed++
+				continue
+			}
+		}
+
+		if groups != nil {
+			if (len(groups) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(groups) [%d] != %d", i, test.input, len(groups), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, groups[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, groups[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+)
+
+type igmpSnoopingGroupsResponse struct {
+	ID      uint64                           `json:"id" xml:"id"`
+	Version string                           `json:"jsonrpc" xml:"jsonrpc"`
+	Result  igmpSnoopingGroupsResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError            `json:"error,omitempty" xml:"error"`
+}
+
+type igmpSnoopingGroupsResponseResult struct {
+	Body igmpSnoopingGroupsResponseResultBody `json:"body" xml:"body"`
+}
+
+type igmpSnoopingGroupsResponseResultBody struct {
+	VlanTable []struct {
+		VlanRow []struct {
+			VlanID     int `json:"vlanid" xml:"vlanid"`
+			GroupTable []struct {
+				GroupRow []struct {
+					GroupAddress string `json:"addr" xml:"addr"`
+					SourceTable  []struct {
+						SourceRow []struct {
+							SourceAddress string `json:"source_addr" xml:"source_addr"`
+							Type          string `json:"type" xml:"type"`
+							PortTable     []struct {
+								PortRow []struct {
+									Port string `json:"port" xml:"port"`
+								} `json:"ROW_port" xml:"ROW_port"`
+							} `json:"TABLE_port" xml:"TABLE_port"`
+						} `json:"ROW_source" xml:"ROW_source"`
+					} `json:"TABLE_source" xml:"TABLE_source"`
+				} `json:"ROW_group" xml:"ROW_group"`
+			} `json:"TABLE_group" xml:"TABLE_group"`
+		} `json:"ROW_vlan" xml:"ROW_vlan"`
+	} `json:"TABLE_vlan" xml:"TABLE_vlan"`
+}
+
+// IGMPSnoopingGroup contains IGMP snooping group membership. The information
+// in the structure is from the output of "show ip igmp snooping groups"
+// command.
+type IGMPSnoopingGroup struct {
+	VlanID        int      `json:"vlan" xml:"vlan"`
+	GroupAddress  string   `json:"group_address" xml:"group_address"`
+	SourceAddress string   `json:"source_address" xml:"source_address"`
+	Type          string   `json:"type" xml:"type"`
+	Ports         []string `json:"ports" xml:"ports"`
+}
+
+// NewIGMPSnoopingGroupsFromString returns IGMPSnoopingGroup instances from
+// an input string.
+func NewIGMPSnoopingGroupsFromString(s string) ([]*IGMPSnoopingGroup, error) {
+	return NewIGMPSnoopingGroupsFromBytes([]byte(s))
+}
+
+// NewIGMPSnoopingGroupsFromBytes returns IGMPSnoopingGroup instances from
+// an input byte array.
+func NewIGMPSnoopingGroupsFromBytes(s []byte) ([]*IGMPSnoopingGroup, error) {
+	var groups []*IGMPSnoopingGroup
+	resp := &igmpSnoopingGroupsResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, tv := range resp.Result.Body.VlanTable {
+		for _, rv := range tv.VlanRow {
+			for _, tg := range rv.GroupTable {
+				for _, rg := range tg.GroupRow {
+					for _, ts := range rg.SourceTable {
+						for _, rs := range ts.SourceRow {
+							group := &IGMPSnoopingGroup{
+								VlanID:        rv.VlanID,
+								GroupAddress:  rg.GroupAddress,
+								SourceAddress: rs.SourceAddress,
+								Type:          rs.Type,
+								Ports:         []string{},
+							}
+							for _, tp := range rs.PortTable {
+								for _, rp := range tp.PortRow {
+									group.Ports = append(group.Ports, rp.Port)
+								}
+							}
+							groups = append(groups, group)
+						}
+					}
+				}
+			}
+		}
+	}
+	return groups, nil
+}
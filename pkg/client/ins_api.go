@@ -14,6 +14,12 @@
 
 package client
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 type insAPIResponse struct {
 	Result insAPIResponseResult `json:"ins_api" xml:"ins_api"`
 }
@@ -34,3 +40,30 @@ type insAPIResponseResultOutputsOutput struct {
 	Message string `json:"msg" xml:"msg"`
 	Input   string `json:"input" xml:"input"`
 }
+
+// newCLIOutputFromBytes returns the output of a command executed via
+// cli_show_ascii request.
+func newCLIOutputFromBytes(s []byte) (string, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return "", fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return "", fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return resp.Result.Outputs.Output.Body, nil
+}
+
+// cliOutputError returns the error reported by a command in its output,
+// e.g. "ERROR: Checkpoint name is in use", or nil when there is none.
+func cliOutputError(s string) error {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "ERROR:") || strings.HasPrefix(line, "% ") {
+			return fmt.Errorf("command returned failure: %s", line)
+		}
+	}
+	return nil
+}
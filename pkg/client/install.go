@@ -0,0 +1,144 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InstallImpactModule is the upgrade impact on a module of InstallImpact.
+type InstallImpactModule struct {
+	Module      int    `json:"module" xml:"module"`
+	Bootable    bool   `json:"bootable" xml:"bootable"`
+	Impact      string `json:"impact" xml:"impact"`
+	InstallType string `json:"install_type" xml:"install_type"`
+	Reason      string `json:"reason" xml:"reason"`
+}
+
+// IsDisruptive returns true when the upgrade disrupts the module.
+func (m *InstallImpactModule) IsDisruptive() bool {
+	return m.Impact == "disruptive"
+}
+
+// InstallImpactImage is an image upgraded on a module of InstallImpact.
+type InstallImpactImage struct {
+	Module          int    `json:"module" xml:"module"`
+	Image           string `json:"image" xml:"image"`
+	RunningVersion  string `json:"running_version" xml:"running_version"`
+	NewVersion      string `json:"new_version" xml:"new_version"`
+	UpgradeRequired bool   `json:"upgrade_required" xml:"upgrade_required"`
+}
+
+// InstallImpact contains the impact of an upgrade. The information in the
+// structure is from the output of "show install all impact" command.
+type InstallImpact struct {
+	Modules []*InstallImpactModule `json:"modules" xml:"modules"`
+	Images  []*InstallImpactImage  `json:"images" xml:"images"`
+}
+
+// IsDisruptive returns true when the upgrade disrupts any of the modules.
+func (i *InstallImpact) IsDisruptive() bool {
+	for _, m := range i.Modules {
+		if m.IsDisruptive() {
+			return true
+		}
+	}
+	return false
+}
+
+// NewInstallImpactFromString returns InstallImpact instance from an input
+// string.
+func NewInstallImpactFromString(s string) (*InstallImpact, error) {
+	return NewInstallImpactFromBytes([]byte(s))
+}
+
+// NewInstallImpactFromBytes returns InstallImpact instance from an input
+// byte array.
+func NewInstallImpactFromBytes(s []byte) (*InstallImpact, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseInstallImpact(resp.Result.Outputs.Output.Body)
+}
+
+// parseInstallImpact parses the compatibility and the image tables of
+// "show install all impact", e.g.
+//
+//	Module  bootable          Impact  Install-type  Reason
+//	------  --------  --------------  ------------  ------
+//	     1       yes      disruptive         reset  default upgrade is not hitless
+//
+//	Module       Image                  Running-Version(pri:alt)           New-Version  Upg-Required
+//	------  ----------  ----------------------------------------  --------------------  ------------
+//	     1        nxos                                7.0(3)I7(5a)                9.2(2)           yes
+func parseInstallImpact(s string) (*InstallImpact, error) {
+	impact := &InstallImpact{
+		Modules: []*InstallImpactModule{},
+		Images:  []*InstallImpactImage{},
+	}
+	var table string
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			table = ""
+			continue
+		}
+		if fields[0] == "Module" && len(fields) > 1 {
+			table = fields[1]
+			continue
+		}
+		module, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		switch table {
+		case "bootable":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("malformed compatibility entry: %s", line)
+			}
+			impact.Modules = append(impact.Modules, &InstallImpactModule{
+				Module:      module,
+				Bootable:    fields[1] == "yes",
+				Impact:      fields[2],
+				InstallType: fields[3],
+				Reason:      strings.Join(fields[4:], " "),
+			})
+		case "Image":
+			if len(fields) != 5 {
+				return nil, fmt.Errorf("malformed image entry: %s", line)
+			}
+			impact.Images = append(impact.Images, &InstallImpactImage{
+				Module:          module,
+				Image:           fields[1],
+				RunningVersion:  fields[2],
+				NewVersion:      fields[3],
+				UpgradeRequired: fields[4] == "yes",
+			})
+		}
+	}
+	if len(impact.Modules) == 0 {
+		return nil, fmt.Errorf("no compatibility check found: %s", s)
+	}
+	return impact, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestParseShowInstallAllImpactOutput(t *testing.T) {
+	fp := "../../assets/requests/resp.show.install.all.impact.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	impact, err := NewInstallImpactFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	expModules := []*InstallImpactModule{
+		{Module: 1, Bootable: true, Impact: "disruptive", InstallType: "reset", Reason: "default upgrade is not hitless"},
+		{Module: 27, Bootable: true, Impact: "non-disruptive", InstallType: "none"},
+	}
+	if !reflect.DeepEqual(expModules, impact.Modules) {
+		t.Fatalf("unexpected modules: %v", impact.Modules)
+	}
+	if len(impact.Images) != 3 {
+		t.Fatalf("unexpected number of images: %d", len(impact.Images))
+	}
+	expImage := &InstallImpactImage{
+		Module:         1,
+		Image:          "bios",
+		RunningVersion: "v07.65(09/04/2018):v07.64(05/16/2018)",
+		NewVersion:     "v07.65(09/04/2018)",
+	}
+	if !reflect.DeepEqual(expImage, impact.Images[1]) {
+		t.Fatalf("unexpected image: %v", impact.Images[1])
+	}
+	if !impact.IsDisruptive() {
+		t.Fatalf("expected disruptive upgrade")
+	}
+
+	fp = "../../assets/requests/resp.show.boot.1.json"
+	content, err = ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	if _, err := NewInstallImpactFromBytes(content); err == nil {
+		t.Fatalf("expected error parsing '%s'", fp)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	//"strings"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type interfacesResponseResultBody struct {
@@ -254,6 +255,27 @@ type interfaceResponseResultBodyInterfaceRow struct {
 	VdcLvlOutUcast   string `json:"vdc_lvl_out_ucast" xml:"vdc_lvl_out_ucast"`
 }
 
+// InterfaceRate contains the input and output rates of an interface,
+// averaged over a load interval. The rates are per second, and the
+// utilization is the percentage of the bandwidth of the interface.
+type InterfaceRate struct {
+	LoadInterval      time.Duration `json:"load_interval" xml:"load_interval"`
+	InputBitRate      uint64        `json:"input_bit_rate" xml:"input_bit_rate"`
+	InputPacketRate   uint64        `json:"input_packet_rate" xml:"input_packet_rate"`
+	OutputBitRate     uint64        `json:"output_bit_rate" xml:"output_bit_rate"`
+	OutputPacketRate  uint64        `json:"output_packet_rate" xml:"output_packet_rate"`
+	InputUtilization  float64       `json:"input_utilization" xml:"input_utilization"`
+	OutputUtilization float64       `json:"output_utilization" xml:"output_utilization"`
+}
+
+// Utilization returns the higher of the input and output utilization.
+func (r *InterfaceRate) Utilization() float64 {
+	if r.InputUtilization > r.OutputUtilization {
+		return r.InputUtilization
+	}
+	return r.OutputUtilization
+}
+
 // Interface contains system information. The information in the structure
 // is from the output of "show interface" command.
 type Interface struct {
@@ -386,6 +408,19 @@ type Interface struct {
 		Rxload      uint64 `json:"rx_load" xml:"rx_load"`
 		Txload      uint64 `json:"tx_load" xml:"tx_load"`
 	}
+	Rates []*InterfaceRate `json:"rates" xml:"rates"`
+}
+
+// MaxUtilization returns the highest utilization of the interface across
+// its load intervals.
+func (intf *Interface) MaxUtilization() float64 {
+	var utilization float64
+	for _, r := range intf.Rates {
+		if u := r.Utilization(); u > utilization {
+			utilization = u
+		}
+	}
+	return utilization
 }
 
 // NewInterfacesFromString returns Interface instance from an input string.
@@ -515,6 +550,46 @@ func parseInterfaceInfo(i int, j *interfaceResponseResultBodyInterfaceRow) *Inte
 		intf.Counters.Intervals.Interval3.TxLoad = i
 	}
 
+	// INFO: rates for load intervals; the load interval, e.g.
+	// eth_load_interval1_rx, is in seconds.
+	for _, interval := range []struct {
+		seconds                                uint64
+		inBits, inPackets, outBits, outPackets uint64
+	}{
+		{
+			intf.Counters.Intervals.Interval1.RxLoad,
+			intf.Counters.Intervals.Interval1.InputRateBits,
+			intf.Counters.Intervals.Interval1.InputRatePackets,
+			intf.Counters.Intervals.Interval1.OutputRateBits,
+			intf.Counters.Intervals.Interval1.OutputRatePackets,
+		},
+		{
+			intf.Counters.Intervals.Interval2.RxLoad,
+			intf.Counters.Intervals.Interval2.InputRateBits,
+			intf.Counters.Intervals.Interval2.InputRatePackets,
+			intf.Counters.Intervals.Interval2.OutputRateBits,
+			intf.Counters.Intervals.Interval2.OutputRatePackets,
+		},
+		{
+			intf.Counters.Intervals.Interval3.RxLoad,
+			intf.Counters.Intervals.Interval3.InputRateBits,
+			intf.Counters.Intervals.Interval3.InputRatePackets,
+			intf.Counters.Intervals.Interval3.OutputRateBits,
+			intf.Counters.Intervals.Interval3.OutputRatePackets,
+		},
+	} {
+		if interval.seconds == 0 {
+			continue
+		}
+		intf.Rates = append(intf.Rates, &InterfaceRate{
+			LoadInterval:     time.Duration(interval.seconds) * time.Second,
+			InputBitRate:     interval.inBits,
+			InputPacketRate:  interval.inPackets,
+			OutputBitRate:    interval.outBits,
+			OutputPacketRate: interval.outPackets,
+		})
+	}
+
 	// INFO: routing metrics
 	intf.Metrics.Bandwidth = j.EthBw
 	intf.Metrics.Delay = j.EthDly
@@ -791,10 +866,21 @@ func parseInterfaceInfo(i int, j *interfaceResponseResultBodyInterfaceRow) *Inte
 
 	// INFO: mgmt interface
 	if j.VdcLvlInAvgBits > 0 {
-		// WONT-DO: j.VdcLvlInAvgBits
-		// WONT-DO: j.VdcLvlInAvgPkts
-		// WONT-DO: j.VdcLvlOutAvgBits
-		// WONT-DO: j.VdcLvlOutAvgPkts
+		// INFO: the averages of the mgmt interface are over one minute
+		rate := &InterfaceRate{
+			LoadInterval: time.Minute,
+			InputBitRate: j.VdcLvlInAvgBits,
+		}
+		if i, err := strconv.ParseUint(j.VdcLvlInAvgPkts, 10, 64); err == nil {
+			rate.InputPacketRate = i
+		}
+		if i, err := strconv.ParseUint(j.VdcLvlOutAvgBits, 10, 64); err == nil {
+			rate.OutputBitRate = i
+		}
+		if i, err := strconv.ParseUint(j.VdcLvlOutAvgPkts, 10, 64); err == nil {
+			rate.OutputPacketRate = i
+		}
+		intf.Rates = append(intf.Rates, rate)
 		intf.Counters.InputPackets = j.VdcLvlInPkts
 		if i, err := strconv.ParseUint(j.VdcLvlInBytes, 10, 64); err == nil {
 			intf.Counters.InputBytes = i
@@ -824,6 +910,15 @@ func parseInterfaceInfo(i int, j *interfaceResponseResultBodyInterfaceRow) *Inte
 			intf.Counters.OutputMulticastPackets = i
 		}
 	}
+
+	// INFO: the bandwidth is in kilobits per second
+	if intf.Metrics.Bandwidth > 0 {
+		bandwidth := float64(intf.Metrics.Bandwidth) * 1000
+		for _, r := range intf.Rates {
+			r.InputUtilization = float64(r.InputBitRate) * 100 / bandwidth
+			r.OutputUtilization = float64(r.OutputBitRate) * 100 / bandwidth
+		}
+	}
 	return intf
 }
 
@@ -0,0 +1,450 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var interfaceNameRegex = regexp.MustCompile(`^(?i)(ethernet|eth|e|port-channel|po|vlan|loopback|lo|mgmt|nve|tunnel)(\d+(?:/\d+){0,2}(?:\.\d+)?)$`)
+
+// InterfaceSpec is the desired configuration of an interface. The zero
+// values leave the configuration as is, except for Addresses, where an
+// empty, but not nil, list removes all the addresses. The first address
+// is the primary one, and the others are secondary.
+type InterfaceSpec struct {
+	Name              string   `json:"name" xml:"name"`
+	Description       string   `json:"description" xml:"description"`
+	AdminState        string   `json:"admin_state" xml:"admin_state"`
+	SwitchportMode    string   `json:"switchport_mode" xml:"switchport_mode"`
+	AccessVlan        int      `json:"access_vlan" xml:"access_vlan"`
+	TrunkAllowedVlans string   `json:"trunk_allowed_vlans" xml:"trunk_allowed_vlans"`
+	NativeVlan        int      `json:"native_vlan" xml:"native_vlan"`
+	MTU               int      `json:"mtu" xml:"mtu"`
+	Speed             string   `json:"speed" xml:"speed"`
+	ChannelGroup      int      `json:"channel_group" xml:"channel_group"`
+	ChannelGroupMode  string   `json:"channel_group_mode" xml:"channel_group_mode"`
+	Addresses         []string `json:"addresses" xml:"addresses"`
+}
+
+// InterfacePlan contains the changes of an interface, with the commands
+// making them.
+type InterfacePlan struct {
+	Interface string   `json:"interface" xml:"interface"`
+	Changes   []string `json:"changes" xml:"changes"`
+	Commands  []string `json:"commands" xml:"commands"`
+	Applied   bool     `json:"applied" xml:"applied"`
+}
+
+// InterfaceReconcileOptions are the options of ReconcileInterfaces. With
+// DryRun, the changes are planned, but not applied.
+type InterfaceReconcileOptions struct {
+	DryRun bool
+}
+
+// IsEmpty returns true when the interface is in the desired state.
+func (p *InterfacePlan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// interfaceState is the configuration of an interface compared with
+// InterfaceSpec.
+type interfaceState struct {
+	description       string
+	adminState        string
+	switchportMode    string
+	accessVlan        int
+	trunkAllowedVlans []int
+	nativeVlan        int
+	mtu               int
+	speed             string
+	channelGroup      int
+	channelGroupMode  string
+	addresses         []string
+}
+
+// PlanInterface returns the changes transforming an interface, i.e. the
+// state from GetInterface and the "interface" section of the running
+// configuration, into its desired configuration. The section is nil when
+// the interface has no configuration.
+func PlanInterface(intf *Interface, config *ConfigLine, spec *InterfaceSpec) (*InterfacePlan, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	name, _ := NormalizeInterfaceName(spec.Name)
+	current, err := newInterfaceState(intf, config)
+	if err != nil {
+		return nil, fmt.Errorf("interface %s: %s", name, err)
+	}
+	plan := &InterfacePlan{
+		Interface: name,
+		Changes:   []string{},
+	}
+	var cmds []string
+	change := func(field string, from, to interface{}, cmd ...string) {
+		plan.Changes = append(plan.Changes, fmt.Sprintf("%s: %v -> %v", field, from, to))
+		cmds = append(cmds, cmd...)
+	}
+
+	mode := current.switchportMode
+	if spec.SwitchportMode != "" && spec.SwitchportMode != current.switchportMode {
+		mode = spec.SwitchportMode
+		switch {
+		case mode == "routed":
+			change("switchport mode", current.switchportMode, mode, "no switchport")
+		case current.switchportMode == "routed":
+			change("switchport mode", current.switchportMode, mode, "switchport", "switchport mode "+mode)
+		default:
+			change("switchport mode", current.switchportMode, mode, "switchport mode "+mode)
+		}
+	}
+	if spec.AccessVlan != 0 && spec.AccessVlan != current.accessVlan {
+		change("access vlan", current.accessVlan, spec.AccessVlan,
+			fmt.Sprintf("switchport access vlan %d", spec.AccessVlan))
+	}
+	if spec.TrunkAllowedVlans != "" {
+		vlans, err := parseVlanRange(spec.TrunkAllowedVlans)
+		if err != nil {
+			return nil, fmt.Errorf("interface %s: %s", name, err)
+		}
+		if formatVlanRange(vlans) != formatVlanRange(current.trunkAllowedVlans) {
+			change("trunk allowed vlans", formatTrunkAllowedVlans(current.trunkAllowedVlans), formatVlanRange(vlans),
+				"switchport trunk allowed vlan "+formatVlanRange(vlans))
+		}
+	}
+	if spec.NativeVlan != 0 && spec.NativeVlan != current.nativeVlan {
+		change("native vlan", current.nativeVlan, spec.NativeVlan,
+			fmt.Sprintf("switchport trunk native vlan %d", spec.NativeVlan))
+	}
+	if spec.MTU != 0 && spec.MTU != current.mtu {
+		change("mtu", current.mtu, spec.MTU, fmt.Sprintf("mtu %d", spec.MTU))
+	}
+	if spec.Speed != "" && spec.Speed != current.speed {
+		change("speed", current.speed, spec.Speed, "speed "+spec.Speed)
+	}
+	if spec.Description != "" && spec.Description != current.description {
+		change("description", fmt.Sprintf("%q", current.description), fmt.Sprintf("%q", spec.Description),
+			"description "+spec.Description)
+	}
+	if spec.Addresses != nil && strings.Join(spec.Addresses, " ") != strings.Join(current.addresses, " ") {
+		change("addresses", current.addresses, spec.Addresses, planInterfaceAddresses(current.addresses, spec.Addresses)...)
+	}
+	if spec.ChannelGroup != 0 && (spec.ChannelGroup != current.channelGroup ||
+		spec.ChannelGroupMode != "" && spec.ChannelGroupMode != current.channelGroupMode) {
+		cmd := fmt.Sprintf("channel-group %d", spec.ChannelGroup)
+		if spec.ChannelGroupMode != "" {
+			cmd += " mode " + spec.ChannelGroupMode
+		}
+		change("channel group", current.channelGroup, spec.ChannelGroup, cmd)
+	}
+	if spec.AdminState != "" && spec.AdminState != current.adminState {
+		cmd := "no shutdown"
+		if spec.AdminState == "down" {
+			cmd = "shutdown"
+		}
+		change("admin state", current.adminState, spec.AdminState, cmd)
+	}
+	if len(cmds) > 0 {
+		plan.Commands = append([]string{"interface " + name}, cmds...)
+	}
+	return plan, nil
+}
+
+// planInterfaceAddresses returns the commands replacing the addresses of
+// an interface. The secondary addresses are removed first, and the new
+// primary address replaces the existing one.
+func planInterfaceAddresses(from, to []string) []string {
+	var cmds []string
+	keep := make(map[string]bool)
+	for i, addr := range to {
+		if i > 0 {
+			keep[addr] = true
+		}
+	}
+	for i, addr := range from {
+		if i > 0 && !keep[addr] {
+			cmds = append(cmds, fmt.Sprintf("no ip address %s secondary", addr))
+		}
+	}
+	switch {
+	case len(to) == 0 && len(from) > 0:
+		cmds = append(cmds, "no ip address "+from[0])
+	case len(to) > 0 && (len(from) == 0 || from[0] != to[0]):
+		cmds = append(cmds, "ip address "+to[0])
+	}
+	existing := make(map[string]bool)
+	for i, addr := range from {
+		if i > 0 {
+			existing[addr] = true
+		}
+	}
+	for i, addr := range to {
+		if i > 0 && !existing[addr] {
+			cmds = append(cmds, fmt.Sprintf("ip address %s secondary", addr))
+		}
+	}
+	return cmds
+}
+
+func (spec *InterfaceSpec) validate() error {
+	if _, err := NormalizeInterfaceName(spec.Name); err != nil {
+		return err
+	}
+	switch spec.AdminState {
+	case "", "up", "down":
+	default:
+		return fmt.Errorf("interface %s: invalid admin state: %s", spec.Name, spec.AdminState)
+	}
+	switch spec.SwitchportMode {
+	case "", "access", "trunk", "routed":
+	default:
+		return fmt.Errorf("interface %s: invalid switchport mode: %s", spec.Name, spec.SwitchportMode)
+	}
+	if spec.SwitchportMode == "routed" && (spec.AccessVlan != 0 || spec.TrunkAllowedVlans != "" || spec.NativeVlan != 0) {
+		return fmt.Errorf("interface %s: vlans on routed port", spec.Name)
+	}
+	for _, vlan := range []int{spec.AccessVlan, spec.NativeVlan} {
+		if vlan < 0 || vlan > 4094 {
+			return fmt.Errorf("interface %s: invalid vlan: %d", spec.Name, vlan)
+		}
+	}
+	if spec.TrunkAllowedVlans != "" {
+		if _, err := parseVlanRange(spec.TrunkAllowedVlans); err != nil {
+			return fmt.Errorf("interface %s: %s", spec.Name, err)
+		}
+	}
+	if spec.MTU != 0 && (spec.MTU < 576 || spec.MTU > 9216) {
+		return fmt.Errorf("interface %s: invalid mtu: %d", spec.Name, spec.MTU)
+	}
+	if strings.Contains(spec.Speed, " ") || hasControlChars(spec.Speed) {
+		return fmt.Errorf("interface %s: invalid speed: %q", spec.Name, spec.Speed)
+	}
+	// the description is up to 254 characters, and a newline would split
+	// the command in two.
+	if len(spec.Description) > 254 || hasControlChars(spec.Description) {
+		return fmt.Errorf("interface %s: invalid description: %q", spec.Name, spec.Description)
+	}
+	if spec.ChannelGroup < 0 || spec.ChannelGroup > 4096 {
+		return fmt.Errorf("interface %s: invalid channel group: %d", spec.Name, spec.ChannelGroup)
+	}
+	switch spec.ChannelGroupMode {
+	case "", "active", "passive", "on":
+	default:
+		return fmt.Errorf("interface %s: invalid channel group mode: %s", spec.Name, spec.ChannelGroupMode)
+	}
+	if spec.ChannelGroupMode != "" && spec.ChannelGroup == 0 {
+		return fmt.Errorf("interface %s: channel group mode without channel group", spec.Name)
+	}
+	if len(spec.Addresses) > 0 && spec.SwitchportMode != "" && spec.SwitchportMode != "routed" {
+		return fmt.Errorf("interface %s: ip addresses on %s port", spec.Name, spec.SwitchportMode)
+	}
+	for _, addr := range spec.Addresses {
+		if _, _, err := net.ParseCIDR(addr); err != nil {
+			return fmt.Errorf("interface %s: invalid ip address: %s", spec.Name, addr)
+		}
+	}
+	return nil
+}
+
+// newInterfaceState returns the configuration of an interface, with the
+// NX-OS defaults for the settings absent from the running configuration.
+func newInterfaceState(intf *Interface, config *ConfigLine) (*interfaceState, error) {
+	state := &interfaceState{
+		adminState:        intf.Props.AdminState,
+		description:       intf.Description,
+		switchportMode:    intf.Props.Mode,
+		accessVlan:        1,
+		trunkAllowedVlans: []int{},
+		nativeVlan:        1,
+		mtu:               int(intf.Props.MTU),
+		speed:             "auto",
+	}
+	if state.switchportMode == "" {
+		state.switchportMode = "access"
+	}
+	state.trunkAllowedVlans = allTrunkVlans()
+	if config == nil {
+		return state, nil
+	}
+	for _, line := range config.Children {
+		fields := strings.Fields(line.Text)
+		switch {
+		case line.Text == "no switchport":
+			state.switchportMode = "routed"
+		case line.Text == "shutdown":
+			state.adminState = "down"
+		case line.Text == "no shutdown":
+			state.adminState = "up"
+		case strings.HasPrefix(line.Text, "description "):
+			state.description = strings.TrimPrefix(line.Text, "description ")
+		case strings.HasPrefix(line.Text, "switchport mode "):
+			state.switchportMode = fields[2]
+		case strings.HasPrefix(line.Text, "switchport access vlan "):
+			state.accessVlan, _ = strconv.Atoi(fields[3])
+		case strings.HasPrefix(line.Text, "switchport trunk allowed vlan "):
+			vlans, err := updateTrunkAllowedVlans(state.trunkAllowedVlans, fields[4:])
+			if err != nil {
+				return nil, err
+			}
+			state.trunkAllowedVlans = vlans
+		case strings.HasPrefix(line.Text, "switchport trunk native vlan "):
+			state.nativeVlan, _ = strconv.Atoi(fields[4])
+		case strings.HasPrefix(line.Text, "mtu "):
+			state.mtu, _ = strconv.Atoi(fields[1])
+		case strings.HasPrefix(line.Text, "speed "):
+			state.speed = fields[1]
+		case strings.HasPrefix(line.Text, "channel-group "):
+			state.channelGroup, _ = strconv.Atoi(fields[1])
+			if len(fields) == 4 && fields[2] == "mode" {
+				state.channelGroupMode = fields[3]
+			}
+		case strings.HasPrefix(line.Text, "ip address "):
+			addr := fields[2]
+			if len(fields) > 3 && fields[3] == "secondary" {
+				state.addresses = append(state.addresses, addr)
+			} else {
+				state.addresses = append([]string{addr}, state.addresses...)
+			}
+		}
+	}
+	return state, nil
+}
+
+func allTrunkVlans() []int {
+	vlans := make([]int, 0, 4094)
+	for vlan := 1; vlan <= 4094; vlan++ {
+		vlans = append(vlans, vlan)
+	}
+	return vlans
+}
+
+// updateTrunkAllowedVlans returns the allowed vlans of a trunk after the
+// arguments of a "switchport trunk allowed vlan" line, e.g. "10,20",
+// "add 200-300", "remove 30", "except 5", "none" or "all". The running
+// configuration splits a long list into a line followed by "add" lines.
+func updateTrunkAllowedVlans(current []int, args []string) ([]int, error) {
+	if len(args) == 1 {
+		switch args[0] {
+		case "none":
+			return []int{}, nil
+		case "all":
+			return allTrunkVlans(), nil
+		}
+		return parseVlanRange(args[0])
+	}
+	if len(args) != 2 {
+		return nil, fmt.Errorf("malformed trunk allowed vlans: %s", strings.Join(args, " "))
+	}
+	vlans, err := parseVlanRange(args[1])
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[int]bool)
+	switch args[0] {
+	case "add":
+		for _, vlan := range current {
+			set[vlan] = true
+		}
+		for _, vlan := range vlans {
+			set[vlan] = true
+		}
+	case "remove":
+		for _, vlan := range current {
+			set[vlan] = true
+		}
+		for _, vlan := range vlans {
+			delete(set, vlan)
+		}
+	case "except":
+		for _, vlan := range allTrunkVlans() {
+			set[vlan] = true
+		}
+		for _, vlan := range vlans {
+			delete(set, vlan)
+		}
+	default:
+		return nil, fmt.Errorf("malformed trunk allowed vlans: %s", strings.Join(args, " "))
+	}
+	result := []int{}
+	for vlan := 1; vlan <= 4094; vlan++ {
+		if set[vlan] {
+			result = append(result, vlan)
+		}
+	}
+	return result, nil
+}
+
+// formatTrunkAllowedVlans returns the allowed vlans of a trunk, or "none"
+// when no vlans are allowed.
+func formatTrunkAllowedVlans(vlans []int) string {
+	if len(vlans) == 0 {
+		return "none"
+	}
+	return formatVlanRange(vlans)
+}
+
+// NormalizeInterfaceName returns the name of an interface as it appears in
+// the configuration, e.g. "Ethernet1/1" for "e1/1" or "eth1/1".
+func NormalizeInterfaceName(name string) (string, error) {
+	m := interfaceNameRegex.FindStringSubmatch(strings.Replace(name, " ", "", -1))
+	if m == nil {
+		return "", fmt.Errorf("invalid interface name: %q", name)
+	}
+	id := m[2]
+	number, _ := strconv.Atoi(strings.SplitN(id, ".", 2)[0])
+	isNumber := !strings.Contains(id, "/")
+	switch strings.ToLower(m[1]) {
+	case "ethernet", "eth", "e":
+		if isNumber {
+			break
+		}
+		return "Ethernet" + id, nil
+	case "port-channel", "po":
+		if !isNumber || number < 1 || number > 4096 {
+			break
+		}
+		return "port-channel" + id, nil
+	case "vlan":
+		if id != strconv.Itoa(number) || number < 1 || number > 4094 {
+			break
+		}
+		return "Vlan" + id, nil
+	case "loopback", "lo":
+		if id != strconv.Itoa(number) || number > 1023 {
+			break
+		}
+		return "loopback" + id, nil
+	case "mgmt":
+		if id != strconv.Itoa(number) {
+			break
+		}
+		return "mgmt" + id, nil
+	case "nve":
+		if id != strconv.Itoa(number) {
+			break
+		}
+		return "nve" + id, nil
+	case "tunnel":
+		if id != strconv.Itoa(number) {
+			break
+		}
+		return "Tunnel" + id, nil
+	}
+	return "", fmt.Errorf("invalid interface name: %q", name)
+}
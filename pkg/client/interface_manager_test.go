@@ -0,0 +1,221 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlanInterface(t *testing.T) {
+	fp := "../../assets/requests/resp.show.interface.ethernet1.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	intf, err := NewInterfaceFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	fp = "../../assets/requests/resp.show.running.config.interface.1.json"
+	content, err = ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	conf, err := NewConfigurationFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	tree, err := conf.Tree()
+	if err != nil {
+		t.Fatalf("failed parsing configuration tree: %v", err)
+	}
+	section := tree.Get("interface Ethernet1/1")
+
+	for i, test := range []struct {
+		spec        *InterfaceSpec
+		config      *ConfigLine
+		expCommands []string
+		shouldErr   bool
+	}{
+		{
+			spec: &InterfaceSpec{
+				Name:              "Ethernet1/1",
+				Description:       "connected to sw02",
+				AdminState:        "up",
+				SwitchportMode:    "trunk",
+				TrunkAllowedVlans: "20,10",
+				MTU:               9216,
+				ChannelGroup:      10,
+			},
+			config: section,
+		},
+		{
+			spec: &InterfaceSpec{
+				Name:              "Ethernet1/1",
+				Description:       "connected to sw03",
+				TrunkAllowedVlans: "10-12,20",
+				NativeVlan:        10,
+				ChannelGroup:      10,
+				ChannelGroupMode:  "passive",
+			},
+			config: section,
+			expCommands: []string{
+				"interface Ethernet1/1",
+				"switchport trunk allowed vlan 10-12,20",
+				"switchport trunk native vlan 10",
+				"description connected to sw03",
+				"channel-group 10 mode passive",
+			},
+		},
+		{
+			spec: &InterfaceSpec{
+				Name:           "Ethernet1/1",
+				AdminState:     "down",
+				SwitchportMode: "routed",
+				MTU:            1500,
+				Addresses:      []string{"10.1.1.1/30", "10.2.2.1/24"},
+			},
+			config: section,
+			expCommands: []string{
+				"interface Ethernet1/1",
+				"no switchport",
+				"mtu 1500",
+				"ip address 10.1.1.1/30",
+				"ip address 10.2.2.1/24 secondary",
+				"shutdown",
+			},
+		},
+		{
+			spec: &InterfaceSpec{
+				Name:      "Ethernet1/1",
+				Addresses: []string{"10.1.1.1/30"},
+			},
+			config: &ConfigLine{
+				Text: "interface Ethernet1/1",
+				Children: []*ConfigLine{
+					{Text: "no switchport"},
+					{Text: "ip address 10.2.2.1/24 secondary"},
+					{Text: "ip address 10.0.0.1/30"},
+				},
+			},
+			expCommands: []string{
+				"interface Ethernet1/1",
+				"no ip address 10.2.2.1/24 secondary",
+				"ip address 10.1.1.1/30",
+			},
+		},
+		{
+			spec:        &InterfaceSpec{Name: "Ethernet1/1", AccessVlan: 10, Speed: "10000"},
+			expCommands: []string{"interface Ethernet1/1", "switchport access vlan 10", "speed 10000"},
+		},
+		{
+			spec:   &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "10,20,200-300"},
+			config: newTrunkConfigLine("switchport trunk allowed vlan 10,20", "switchport trunk allowed vlan add 200-300"),
+		},
+		{
+			spec:   &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "10,30"},
+			config: newTrunkConfigLine("switchport trunk allowed vlan 10,20,30", "switchport trunk allowed vlan remove 20"),
+		},
+		{
+			spec:   &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "1-4,6-4094"},
+			config: newTrunkConfigLine("switchport trunk allowed vlan except 5"),
+		},
+		{
+			spec:        &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "10"},
+			config:      newTrunkConfigLine("switchport trunk allowed vlan none"),
+			expCommands: []string{"interface Ethernet1/1", "switchport trunk allowed vlan 10"},
+		},
+		{
+			spec:      &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "10"},
+			config:    newTrunkConfigLine("switchport trunk allowed vlan 10", "switchport trunk allowed vlan add 4095"),
+			shouldErr: true,
+		},
+		{
+			spec:      &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "10"},
+			config:    newTrunkConfigLine("switchport trunk allowed vlan insert 20"),
+			shouldErr: true,
+		},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", SwitchportMode: "routed", AccessVlan: 10}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", SwitchportMode: "routed", TrunkAllowedVlans: "10"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", SwitchportMode: "routed", NativeVlan: 10}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", ChannelGroupMode: "active"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "10,x"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", SwitchportMode: "fex-fabric"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", TrunkAllowedVlans: "10-5000"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", MTU: 10000}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", Description: "a\nreload"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", Description: "a\rreload"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", Description: strings.Repeat("a", 255)}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", Speed: "1000\nreload"}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", SwitchportMode: "access", Addresses: []string{"10.0.0.1/24"}}, shouldErr: true},
+		{spec: &InterfaceSpec{Name: "Ethernet1/1", Addresses: []string{"10.0.0.1"}}, shouldErr: true},
+	} {
+		plan, err := PlanInterface(intf, test.config, test.spec)
+		if err != nil {
+			if !test.shouldErr {
+				t.Fatalf("test %d: unexpected error: %v", i, err)
+			}
+			continue
+		}
+		if test.shouldErr {
+			t.Fatalf("test %d: expected error, got: %v", i, plan.Commands)
+		}
+		if !reflect.DeepEqual(test.expCommands, plan.Commands) {
+			t.Fatalf("test %d: unexpected commands: %v, changes: %v", i, plan.Commands, plan.Changes)
+		}
+		if plan.IsEmpty() != (len(test.expCommands) == 0) {
+			t.Fatalf("test %d: unexpected changes: %v", i, plan.Changes)
+		}
+	}
+}
+
+func newTrunkConfigLine(lines ...string) *ConfigLine {
+	config := &ConfigLine{
+		Text:     "interface Ethernet1/1",
+		Children: []*ConfigLine{{Text: "switchport mode trunk"}},
+	}
+	for _, line := range lines {
+		config.Children = append(config.Children, &ConfigLine{Text: line})
+	}
+	return config
+}
+
+func TestNormalizeInterfaceName(t *testing.T) {
+	for name, exp := range map[string]string{
+		"e1/1":            "Ethernet1/1",
+		"eth1/49/2":       "Ethernet1/49/2",
+		"Ethernet 1/1.10": "Ethernet1/1.10",
+		"po10":            "port-channel10",
+		"vlan100":         "Vlan100",
+		"lo0":             "loopback0",
+		"mgmt0":           "mgmt0",
+	} {
+		s, err := NormalizeInterfaceName(name)
+		if err != nil {
+			t.Fatalf("failed normalizing %q: %v", name, err)
+		}
+		if s != exp {
+			t.Fatalf("unexpected name of %q: %s", name, s)
+		}
+	}
+	for _, name := range []string{"", "e1", "ethernet1/x", "po0", "vlan4095", "lo1/1", "gi0/1"} {
+		if _, err := NormalizeInterfaceName(name); err == nil {
+			t.Fatalf("expected error for interface name %q", name)
+		}
+	}
+}
@@ -17,7 +17,9 @@ package client
 import (
 	"fmt"
 	"io/ioutil"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestParseShowInterfaceJsonOutput(t *testing.T) {
@@ -121,6 +123,23 @@ func TestParseShowInterfaceEthernet(t *testing.T) {
 	if len(interfaces) != 1 {
 		t.Fatalf("FAIL: in '%s', error: len(interfaces) [%d] != 1", fp, len(interfaces))
 	}
+	expRates := []*InterfaceRate{
+		{
+			LoadInterval:      30 * time.Second,
+			InputBitRate:      833008504,
+			InputPacketRate:   85850,
+			OutputBitRate:     2835385744,
+			OutputPacketRate:  235063,
+			InputUtilization:  0.833008504,
+			OutputUtilization: 2.835385744,
+		},
+	}
+	if !reflect.DeepEqual(expRates, interfaces[0].Rates) {
+		t.Fatalf("FAIL: in '%s', error: unexpected rates: %v", fp, interfaces[0].Rates[0])
+	}
+	if interfaces[0].MaxUtilization() != 2.835385744 {
+		t.Fatalf("FAIL: in '%s', error: unexpected utilization: %f", fp, interfaces[0].MaxUtilization())
+	}
 	t.Logf("PASS: ok")
 }
 
@@ -157,5 +176,11 @@ func TestParseShowInterfaceMgmt(t *testing.T) {
 	if len(interfaces) != 1 {
 		t.Fatalf("FAIL: in '%s', error: len(interfaces) [%d] != 1", fp, len(interfaces))
 	}
+	rates := interfaces[0].Rates
+	if len(rates) != 1 || rates[0].LoadInterval != time.Minute ||
+		rates[0].InputBitRate != 1104 || rates[0].OutputBitRate != 14336 ||
+		rates[0].InputPacketRate != 1 || rates[0].OutputPacketRate != 1 {
+		t.Fatalf("FAIL: in '%s', error: unexpected rates: %v", fp, rates)
+	}
 	t.Logf("PASS: ok")
 }
@@ -0,0 +1,116 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type licenseUsageResponse struct {
+	ID      uint64                     `json:"id" xml:"id"`
+	Version string                     `json:"jsonrpc" xml:"jsonrpc"`
+	Result  licenseUsageResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError      `json:"error,omitempty" xml:"error"`
+}
+
+type licenseUsageResponseResult struct {
+	Body licenseUsageResponseResultBody `json:"body" xml:"body"`
+}
+
+type licenseUsageResponseResultBody struct {
+	LicenseTable []struct {
+		LicenseRow []struct {
+			Feature   string `json:"feature_name" xml:"feature_name"`
+			Installed string `json:"installed" xml:"installed"`
+			Count     string `json:"lic_count" xml:"lic_count"`
+			Status    string `json:"status" xml:"status"`
+			Expiry    string `json:"expiry_date" xml:"expiry_date"`
+			Comments  string `json:"comments" xml:"comments"`
+		} `json:"ROW_lic_usage" xml:"ROW_lic_usage"`
+	} `json:"TABLE_lic_usage" xml:"TABLE_lic_usage"`
+}
+
+// LicenseUsage contains the usage of a license. The information in the
+// structure is from the output of "show license usage" command. The Count
+// is -1 for the licenses that are not counted, and the Expiry is zero for
+// the licenses that never expire or are not installed.
+type LicenseUsage struct {
+	Feature   string    `json:"feature" xml:"feature"`
+	Installed bool      `json:"installed" xml:"installed"`
+	Count     int       `json:"count" xml:"count"`
+	Status    string    `json:"status" xml:"status"`
+	Expiry    time.Time `json:"expiry" xml:"expiry"`
+	Comments  string    `json:"comments" xml:"comments"`
+}
+
+// IsInUse returns true when the license is in use.
+func (l *LicenseUsage) IsInUse() bool {
+	return strings.EqualFold(l.Status, "In use")
+}
+
+// ExpiresBefore returns true when the license is installed and expires
+// before a particular time.
+func (l *LicenseUsage) ExpiresBefore(t time.Time) bool {
+	return l.Installed && !l.Expiry.IsZero() && l.Expiry.Before(t)
+}
+
+// NewLicenseUsageFromString returns LicenseUsage instances from an input
+// string.
+func NewLicenseUsageFromString(s string) ([]*LicenseUsage, error) {
+	return NewLicenseUsageFromBytes([]byte(s))
+}
+
+// NewLicenseUsageFromBytes returns LicenseUsage instances from an input
+// byte array.
+func NewLicenseUsageFromBytes(s []byte) ([]*LicenseUsage, error) {
+	var licenses []*LicenseUsage
+	resp := &licenseUsageResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.LicenseTable {
+		for _, r := range t.LicenseRow {
+			l := &LicenseUsage{
+				Feature:   r.Feature,
+				Installed: strings.EqualFold(r.Installed, "Yes"),
+				Count:     -1,
+				Status:    r.Status,
+				Comments:  r.Comments,
+			}
+			if count, err := strconv.Atoi(strings.TrimSpace(r.Count)); err == nil {
+				l.Count = count
+			}
+			// the expiry date is either "Never", "-", or a date, e.g.
+			// "31 Dec 2018".
+			if expiry, err := time.Parse("02 Jan 2006", strings.TrimSpace(r.Expiry)); err == nil {
+				l.Expiry = expiry
+			}
+			licenses = append(licenses, l)
+		}
+	}
+	return licenses, nil
+}
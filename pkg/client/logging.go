@@ -0,0 +1,111 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const logTimestampFormat = "2006 Jan _2 15:04:05"
+
+var (
+	logTimestampRegex = regexp.MustCompile(`^(\d{4} \w{3}\s+\d{1,2} \d{2}:\d{2}:\d{2})(\.\d+)?(?: [A-Z]{3,4}:)?\s+(.*)$`)
+	logMessageRegex   = regexp.MustCompile(`^(?:(\S+) )?%([A-Z0-9_]+)-(\d)-([A-Z0-9_]+):\s*(.*)$`)
+)
+
+// LogMessage is a message from the system log. The information in the
+// structure is from the output of "show logging logfile" command.
+type LogMessage struct {
+	Timestamp time.Time `json:"timestamp" xml:"timestamp"`
+	Host      string    `json:"host" xml:"host"`
+	Facility  string    `json:"facility" xml:"facility"`
+	Severity  int       `json:"severity" xml:"severity"`
+	Mnemonic  string    `json:"mnemonic" xml:"mnemonic"`
+	Text      string    `json:"text" xml:"text"`
+}
+
+// NewLogMessagesFromString returns LogMessage instances from an input string.
+func NewLogMessagesFromString(s string) ([]*LogMessage, error) {
+	return NewLogMessagesFromBytes([]byte(s))
+}
+
+// NewLogMessagesFromBytes returns LogMessage instances from an input byte
+// array. The timestamps are interpreted as UTC.
+func NewLogMessagesFromBytes(s []byte) ([]*LogMessage, error) {
+	return newLogMessagesFromBytes(s, time.UTC)
+}
+
+func newLogMessagesFromBytes(s []byte, loc *time.Location) ([]*LogMessage, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseLogMessages(resp.Result.Outputs.Output.Body, loc), nil
+}
+
+// parseLogMessages parses system log lines, e.g.
+//
+//	2018 Dec 18 21:20:43 ny-sw01 %ETHPORT-5-IF_DOWN_LINK_FAILURE: Interface Ethernet1/1 is down (Link failure)
+//
+// The lines without a timestamp are the continuation of the preceding message.
+func parseLogMessages(s string, loc *time.Location) []*LogMessage {
+	messages := []*LogMessage{}
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if line == "" {
+			continue
+		}
+		m := logTimestampRegex.FindStringSubmatch(line)
+		if m == nil {
+			if len(messages) > 0 {
+				last := messages[len(messages)-1]
+				last.Text += "\n" + strings.TrimSpace(line)
+			}
+			continue
+		}
+		ts, err := time.ParseInLocation(logTimestampFormat, m[1], loc)
+		if err != nil {
+			continue
+		}
+		if m[2] != "" {
+			if fraction, err := strconv.ParseFloat(m[2], 64); err == nil {
+				ts = ts.Add(time.Duration(fraction * float64(time.Second)))
+			}
+		}
+		msg := &LogMessage{
+			Timestamp: ts,
+			Text:      m[3],
+		}
+		if mm := logMessageRegex.FindStringSubmatch(m[3]); mm != nil {
+			msg.Host = mm[1]
+			msg.Facility = mm[2]
+			msg.Severity, _ = strconv.Atoi(mm[3])
+			msg.Mnemonic = mm[4]
+			msg.Text = mm[5]
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
@@ -0,0 +1,128 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShowLoggingLogfileOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *LogMessage
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.logging.logfile.1",
+			exp: &LogMessage{
+				Timestamp: time.Date(2018, time.December, 18, 20, 55, 2, 0, time.UTC),
+				Host:      "ny-sw01",
+				Facility:  "VSHD",
+				Severity:  5,
+				Mnemonic:  "VSHD_SYSLOG_CONFIG_I",
+				Text:      "Configured from vty by admin on 10.0.0.5@pts/0",
+			},
+			count:      5,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "error.invalid.params",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewLogMessagesFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestParseLogMessages(t *testing.T) {
+	fp := "../../assets/requests/resp.show.logging.logfile.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	messages, err := NewLogMessagesFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	exp := &LogMessage{
+		Timestamp: time.Date(2018, time.December, 18, 21, 20, 44, 512000000, time.UTC),
+		Host:      "ny-sw01",
+		Facility:  "ETHPORT",
+		Severity:  5,
+		Mnemonic:  "IF_DOWN_INTERFACE_REMOVED",
+		Text:      "Interface Ethernet1/1 is down (Interface removed)",
+	}
+	if !reflect.DeepEqual(exp, messages[2]) {
+		t.Fatalf("unexpected message: %+v", messages[2])
+	}
+	if messages[3].Text != "bgp- [5873] (default) neighbor 10.10.1.1 Down - holdtimer expired error\nadditional details follow" {
+		t.Fatalf("unexpected continuation: %q", messages[3].Text)
+	}
+	if messages[4].Mnemonic != "" || messages[4].Text != "ny-sw01 last message repeated 2 times" {
+		t.Fatalf("unexpected unstructured message: %+v", messages[4])
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+	"time"
+)
+
+type mrouteResponse struct {
+	ID      uint64                `json:"id" xml:"id"`
+	Version string                `json:"jsonrpc" xml:"jsonrpc"`
+	Result  mrouteResponseResult  `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError `json:"error,omitempty" xml:"error"`
+}
+
+type mrouteResponseResult struct {
+	Body mrouteResponseResultBody `json:"body" xml:"body"`
+}
+
+type mrouteResponseResultBody struct {
+	VrfTable []struct {
+		VrfRow []struct {
+			VrfName    string `json:"vrf-name" xml:"vrf-name"`
+			RouteTable []struct {
+				RouteRow []mrouteResponseResultBodyRouteRow `json:"ROW_one_route" xml:"ROW_one_route"`
+			} `json:"TABLE_one_route" xml:"TABLE_one_route"`
+		} `json:"ROW_vrf" xml:"ROW_vrf"`
+	} `json:"TABLE_vrf" xml:"TABLE_vrf"`
+}
+
+type mrouteResponseResultBodyRouteRow struct {
+	SourceAddress      string `json:"source-addr" xml:"source-addr"`
+	GroupAddress       string `json:"group-addr" xml:"group-addr"`
+	Uptime             string `json:"uptime" xml:"uptime"`
+	IncomingInterface  string `json:"route-iif" xml:"route-iif"`
+	RPFNeighbor        string `json:"rpf-nbr" xml:"rpf-nbr"`
+	OutgoingCount      int    `json:"oif-count" xml:"oif-count"`
+	OutgoingInterfaces []struct {
+		OutgoingRow []struct {
+			Name     string `json:"oif-name" xml:"oif-name"`
+			Uptime   string `json:"oif-uptime" xml:"oif-uptime"`
+			Protocol string `json:"oif-protocol" xml:"oif-protocol"`
+		} `json:"ROW_oif" xml:"ROW_oif"`
+	} `json:"TABLE_oif" xml:"TABLE_oif"`
+}
+
+// MRouteInterface is an entry of the outgoing interface list of MRoute.
+type MRouteInterface struct {
+	Name     string        `json:"name" xml:"name"`
+	Uptime   time.Duration `json:"uptime" xml:"uptime"`
+	Protocol string        `json:"protocol" xml:"protocol"`
+}
+
+// MRoute contains multicast routing table entry. The information in the
+// structure is from the output of "show ip mroute" command.
+type MRoute struct {
+	Vrf                string            `json:"vrf" xml:"vrf"`
+	SourceAddress      string            `json:"source_address" xml:"source_address"`
+	GroupAddress       string            `json:"group_address" xml:"group_address"`
+	Uptime             time.Duration     `json:"uptime" xml:"uptime"`
+	IncomingInterface  string            `json:"incoming_interface" xml:"incoming_interface"`
+	RPFNeighbor        string            `json:"rpf_neighbor" xml:"rpf_neighbor"`
+	OutgoingInterfaces []MRouteInterface `json:"outgoing_interfaces" xml:"outgoing_interfaces"`
+}
+
+// IsWildcard returns true for (*,G) entries.
+func (r *MRoute) IsWildcard() bool {
+	return r.SourceAddress == "*"
+}
+
+// NewMRoutesFromString returns MRoute instances from an input string.
+func NewMRoutesFromString(s string) ([]*MRoute, error) {
+	return NewMRoutesFromBytes([]byte(s))
+}
+
+// NewMRoutesFromBytes returns MRoute instances from an input byte array.
+func NewMRoutesFromBytes(s []byte) ([]*MRoute, error) {
+	var routes []*MRoute
+	resp := &mrouteResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, tv := range resp.Result.Body.VrfTable {
+		for _, rv := range tv.VrfRow {
+			for _, tr := range rv.RouteTable {
+				for _, rr := range tr.RouteRow {
+					route := &MRoute{
+						Vrf:                rv.VrfName,
+						SourceAddress:      rr.SourceAddress,
+						GroupAddress:       rr.GroupAddress,
+						Uptime:             ParseDuration(rr.Uptime),
+						IncomingInterface:  rr.IncomingInterface,
+						RPFNeighbor:        rr.RPFNeighbor,
+						OutgoingInterfaces: []MRouteInterface{},
+					}
+					if route.SourceAddress == "" || strings.HasPrefix(route.SourceAddress, "*") {
+						route.SourceAddress = "*"
+					}
+					for _, to := range rr.OutgoingInterfaces {
+						for _, ro := range to.OutgoingRow {
+							route.OutgoingInterfaces = append(route.OutgoingInterfaces, MRouteInterface{
+								Name:     ro.Name,
+								Uptime:   ParseDuration(ro.Uptime),
+								Protocol: ro.Protocol,
+							})
+						}
+					}
+					routes = append(routes, route)
+				}
+			}
+		}
+	}
+	return routes, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strconv"
+	"time"
+)
+
+type ntpPeerStatusResponse struct {
+	ID      uint64                      `json:"id" xml:"id"`
+	Version string                      `json:"jsonrpc" xml:"jsonrpc"`
+	Result  ntpPeerStatusResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError       `json:"error,omitempty" xml:"error"`
+}
+
+type ntpPeerStatusResponseResult struct {
+	Body ntpPeerStatusResponseResultBody `json:"body" xml:"body"`
+}
+
+type ntpPeerStatusResponseResultBody struct {
+	PeerTable []struct {
+		PeerRow []struct {
+			SyncMode string  `json:"syncmode" xml:"syncmode"`
+			Remote   string  `json:"remote" xml:"remote"`
+			Local    string  `json:"local" xml:"local"`
+			Stratum  int     `json:"st" xml:"st"`
+			Poll     int64   `json:"poll" xml:"poll"`
+			Reach    string  `json:"reach" xml:"reach"`
+			Delay    float64 `json:"delay" xml:"delay"`
+			Vrf      string  `json:"vrf" xml:"vrf"`
+		} `json:"ROW_peersstatus" xml:"ROW_peersstatus"`
+	} `json:"TABLE_peersstatus" xml:"TABLE_peersstatus"`
+}
+
+// NTPPeer contains NTP peer information. The information in the structure
+// is from the output of "show ntp peer-status" command. The Mode is
+// "active" or "passive" for a peer, "client" for a polled server, and
+// empty for the peer selected for synchronization.
+type NTPPeer struct {
+	Address  string        `json:"address" xml:"address"`
+	Local    string        `json:"local" xml:"local"`
+	Vrf      string        `json:"vrf" xml:"vrf"`
+	Mode     string        `json:"mode" xml:"mode"`
+	Stratum  int           `json:"stratum" xml:"stratum"`
+	Poll     time.Duration `json:"poll" xml:"poll"`
+	Reach    uint8         `json:"reach" xml:"reach"`
+	Delay    time.Duration `json:"delay" xml:"delay"`
+	Selected bool          `json:"selected" xml:"selected"`
+}
+
+// IsSynced returns true when the switch is synchronized to the peer.
+func (p *NTPPeer) IsSynced() bool {
+	return p.Selected
+}
+
+// IsReachable returns true when the peer responded to the last poll.
+func (p *NTPPeer) IsReachable() bool {
+	return p.Reach&1 == 1
+}
+
+// NewNTPPeersFromString returns NTPPeer instances from an input string.
+func NewNTPPeersFromString(s string) ([]*NTPPeer, error) {
+	return NewNTPPeersFromBytes([]byte(s))
+}
+
+// NewNTPPeersFromBytes returns NTPPeer instances from an input byte array.
+func NewNTPPeersFromBytes(s []byte) ([]*NTPPeer, error) {
+	var peers []*NTPPeer
+	resp := &ntpPeerStatusResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.PeerTable {
+		for _, r := range t.PeerRow {
+			p := &NTPPeer{
+				Address:  r.Remote,
+				Local:    r.Local,
+				Vrf:      r.Vrf,
+				Stratum:  r.Stratum,
+				Poll:     time.Duration(r.Poll) * time.Second,
+				Delay:    time.Duration(r.Delay * float64(time.Second)),
+				Selected: r.SyncMode == "*",
+			}
+			switch r.SyncMode {
+			case "+":
+				p.Mode = "active"
+			case "-":
+				p.Mode = "passive"
+			case "=":
+				p.Mode = "client"
+			}
+			// the reachability register is an octal bit mask of the last
+			// eight polls.
+			if reach, err := strconv.ParseUint(r.Reach, 8, 8); err == nil {
+				p.Reach = uint8(reach)
+			}
+			peers = append(peers, p)
+		}
+	}
+	return peers, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ObjectGroupEntry is an entry of ObjectGroup.
+type ObjectGroupEntry struct {
+	Sequence int    `json:"sequence" xml:"sequence"`
+	Value    string `json:"value" xml:"value"`
+}
+
+// ObjectGroup contains object group information. The information in the
+// structure is from the output of "show object-group" command.
+type ObjectGroup struct {
+	Name    string             `json:"name" xml:"name"`
+	Family  string             `json:"family" xml:"family"`
+	Type    string             `json:"type" xml:"type"`
+	Entries []ObjectGroupEntry `json:"entries" xml:"entries"`
+}
+
+// NewObjectGroupsFromString returns ObjectGroup instances from an input string.
+func NewObjectGroupsFromString(s string) ([]*ObjectGroup, error) {
+	return NewObjectGroupsFromBytes([]byte(s))
+}
+
+// NewObjectGroupsFromBytes returns ObjectGroup instances from an input byte array.
+func NewObjectGroupsFromBytes(s []byte) ([]*ObjectGroup, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseObjectGroups(resp.Result.Outputs.Output.Body)
+}
+
+// parseObjectGroups parses the text output of "show object-group", e.g.
+//
+//	IPv4 address object-group WEB-SERVERS
+//	        10 host 10.1.1.10
+//	        20 10.1.2.0/24
+//	IPv4 port object-group WEB-PORTS
+//	        10 eq 443
+func parseObjectGroups(s string) ([]*ObjectGroup, error) {
+	var groups []*ObjectGroup
+	var group *ObjectGroup
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) == 4 && fields[2] == "object-group" {
+			group = &ObjectGroup{
+				Name:    fields[3],
+				Family:  strings.ToLower(fields[0]),
+				Type:    fields[1],
+				Entries: []ObjectGroupEntry{},
+			}
+			groups = append(groups, group)
+			continue
+		}
+		if group == nil {
+			return nil, fmt.Errorf("object-group entry without a group: %s", line)
+		}
+		seq, err := strconv.Atoi(fields[0])
+		if err != nil || len(fields) < 2 {
+			return nil, fmt.Errorf("malformed object-group entry: %s", line)
+		}
+		group.Entries = append(group.Entries, ObjectGroupEntry{
+			Sequence: seq,
+			Value:    strings.Join(fields[1:], " "),
+		})
+	}
+	return groups, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+	"time"
+)
+
+type pimNeighborResponse struct {
+	ID      uint64                    `json:"id" xml:"id"`
+	Version string                    `json:"jsonrpc" xml:"jsonrpc"`
+	Result  pimNeighborResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError     `json:"error,omitempty" xml:"error"`
+}
+
+type pimNeighborResponseResult struct {
+	Body pimNeighborResponseResultBody `json:"body" xml:"body"`
+}
+
+type pimNeighborResponseResultBody struct {
+	VrfTable []struct {
+		VrfRow []struct {
+			VrfName       string `json:"vrf-name" xml:"vrf-name"`
+			NeighborTable []struct {
+				NeighborRow []pimNeighborResponseResultBodyNeighborRow `json:"ROW_neighbor" xml:"ROW_neighbor"`
+			} `json:"TABLE_neighbor" xml:"TABLE_neighbor"`
+		} `json:"ROW_vrf" xml:"ROW_vrf"`
+	} `json:"TABLE_vrf" xml:"TABLE_vrf"`
+}
+
+type pimNeighborResponseResultBodyNeighborRow struct {
+	Address      string `json:"nbr-addr" xml:"nbr-addr"`
+	Interface    string `json:"if-name" xml:"if-name"`
+	Uptime       string `json:"uptime" xml:"uptime"`
+	Expires      string `json:"expires" xml:"expires"`
+	DRPriority   int    `json:"dr-priority" xml:"dr-priority"`
+	BidirCapable string `json:"bidir-capable" xml:"bidir-capable"`
+	BFDState     string `json:"bfd-state" xml:"bfd-state"`
+}
+
+// PIMNeighbor contains PIM neighbor information. The information in the
+// structure is from the output of "show ip pim neighbor vrf all" command.
+type PIMNeighbor struct {
+	Vrf          string        `json:"vrf" xml:"vrf"`
+	Address      string        `json:"address" xml:"address"`
+	Interface    string        `json:"interface" xml:"interface"`
+	Uptime       time.Duration `json:"uptime" xml:"uptime"`
+	Expires      time.Duration `json:"expires" xml:"expires"`
+	DRPriority   int           `json:"dr_priority" xml:"dr_priority"`
+	BidirCapable bool          `json:"bidir_capable" xml:"bidir_capable"`
+	BFDState     string        `json:"bfd_state" xml:"bfd_state"`
+}
+
+// NewPIMNeighborsFromString returns PIMNeighbor instances from an input string.
+func NewPIMNeighborsFromString(s string) ([]*PIMNeighbor, error) {
+	return NewPIMNeighborsFromBytes([]byte(s))
+}
+
+// NewPIMNeighborsFromBytes returns PIMNeighbor instances from an input byte array.
+func NewPIMNeighborsFromBytes(s []byte) ([]*PIMNeighbor, error) {
+	var neighbors []*PIMNeighbor
+	resp := &pimNeighborResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, tv := range resp.Result.Body.VrfTable {
+		for _, rv := range tv.VrfRow {
+			for _, tn := range rv.NeighborTable {
+				for _, rn := range tn.NeighborRow {
+					neighbors = append(neighbors, &PIMNeighbor{
+						Vrf:          rv.VrfName,
+						Address:      rn.Address,
+						Interface:    rn.Interface,
+						Uptime:       ParseDuration(rn.Uptime),
+						Expires:      ParseDuration(rn.Expires),
+						DRPriority:   rn.DRPriority,
+						BidirCapable: strings.EqualFold(rn.BidirCapable, "yes"),
+						BFDState:     rn.BFDState,
+					})
+				}
+			}
+		}
+	}
+	return neighbors, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	policyMapServicePolicyRegex = regexp.MustCompile(`^Service-policy \((\S+)\) (input|output):\s+(\S+)`)
+	policyMapClassRegex         = regexp.MustCompile(`^Class-map \((\S+)\):\s+(\S+)(?: \((\S+)\))?`)
+	policyMapPacketsRegex       = regexp.MustCompile(`^(\d+) packets`)
+	policyMapPolicerRegex       = regexp.MustCompile(`^(conformed|exceeded|violated) (\d+) bytes,\s*(\d+) bps action:\s*(\S+)`)
+)
+
+// PolicerCounter is a policer counter of PolicyMapClass.
+type PolicerCounter struct {
+	Bytes  uint64 `json:"bytes" xml:"bytes"`
+	Rate   uint64 `json:"rate" xml:"rate"`
+	Action string `json:"action" xml:"action"`
+}
+
+// Policer contains the policer configuration and counters of PolicyMapClass.
+type Policer struct {
+	Config    string         `json:"config" xml:"config"`
+	Conformed PolicerCounter `json:"conformed" xml:"conformed"`
+	Exceeded  PolicerCounter `json:"exceeded" xml:"exceeded"`
+	Violated  PolicerCounter `json:"violated" xml:"violated"`
+}
+
+// PolicyMapMatch is a match criteria of PolicyMapClass.
+type PolicyMapMatch struct {
+	Criteria string `json:"criteria" xml:"criteria"`
+	Packets  uint64 `json:"packets" xml:"packets"`
+}
+
+// PolicyMapClass is a class-map of ServicePolicy.
+type PolicyMapClass struct {
+	Name      string           `json:"name" xml:"name"`
+	MatchType string           `json:"match_type" xml:"match_type"`
+	Packets   uint64           `json:"packets" xml:"packets"`
+	Matches   []PolicyMapMatch `json:"matches" xml:"matches"`
+	Actions   []string         `json:"actions" xml:"actions"`
+	Policer   *Policer         `json:"policer,omitempty" xml:"policer,omitempty"`
+}
+
+// ServicePolicy is a policy-map attached to an interface.
+type ServicePolicy struct {
+	Name      string           `json:"name" xml:"name"`
+	Type      string           `json:"type" xml:"type"`
+	Direction string           `json:"direction" xml:"direction"`
+	Classes   []PolicyMapClass `json:"classes" xml:"classes"`
+}
+
+// PolicyMapInterface contains the service policies attached to an
+// interface and their statistics. The information in the structure is
+// from the output of "show policy-map interface" command.
+type PolicyMapInterface struct {
+	Interface string          `json:"interface" xml:"interface"`
+	Policies  []ServicePolicy `json:"policies" xml:"policies"`
+}
+
+// NewPolicyMapInterfaceFromString returns PolicyMapInterface instance from
+// an input string.
+func NewPolicyMapInterfaceFromString(s string) (*PolicyMapInterface, error) {
+	return NewPolicyMapInterfaceFromBytes([]byte(s))
+}
+
+// NewPolicyMapInterfaceFromBytes returns PolicyMapInterface instance from
+// an input byte array.
+func NewPolicyMapInterfaceFromBytes(s []byte) (*PolicyMapInterface, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parsePolicyMapInterface(resp.Result.Outputs.Output.Body)
+}
+
+func parsePolicyMapInterface(s string) (*PolicyMapInterface, error) {
+	pmi := &PolicyMapInterface{
+		Policies: []ServicePolicy{},
+	}
+	var policy *ServicePolicy
+	var class *PolicyMapClass
+	// inMatch indicates that the packet counter belongs to the last
+	// match criteria, and inAggregate to the class itself.
+	var inMatch, inAggregate bool
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := policyMapServicePolicyRegex.FindStringSubmatch(line); m != nil {
+			pmi.Policies = append(pmi.Policies, ServicePolicy{
+				Type:      m[1],
+				Direction: m[2],
+				Name:      m[3],
+				Classes:   []PolicyMapClass{},
+			})
+			policy = &pmi.Policies[len(pmi.Policies)-1]
+			class = nil
+			continue
+		}
+		if policy == nil {
+			if pmi.Interface == "" && !strings.ContainsAny(line, ": ") {
+				pmi.Interface = line
+			}
+			continue
+		}
+		if m := policyMapClassRegex.FindStringSubmatch(line); m != nil {
+			policy.Classes = append(policy.Classes, PolicyMapClass{
+				Name:      m[2],
+				MatchType: m[3],
+				Matches:   []PolicyMapMatch{},
+				Actions:   []string{},
+			})
+			class = &policy.Classes[len(policy.Classes)-1]
+			inMatch, inAggregate = false, false
+			continue
+		}
+		if class == nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "Aggregate forwarded"):
+			inMatch, inAggregate = false, true
+		case strings.HasPrefix(line, "Match:"):
+			class.Matches = append(class.Matches, PolicyMapMatch{
+				Criteria: strings.TrimSpace(strings.TrimPrefix(line, "Match:")),
+			})
+			inMatch, inAggregate = true, false
+		case strings.HasPrefix(line, "Slot"):
+			inMatch, inAggregate = false, false
+		case policyMapPacketsRegex.MatchString(line):
+			m := policyMapPacketsRegex.FindStringSubmatch(line)
+			packets, _ := strconv.ParseUint(m[1], 10, 64)
+			switch {
+			case inMatch:
+				class.Matches[len(class.Matches)-1].Packets = packets
+			case inAggregate:
+				class.Packets = packets
+			}
+		case strings.HasPrefix(line, "police "):
+			class.Policer = &Policer{
+				Config: strings.TrimPrefix(line, "police "),
+			}
+			inMatch, inAggregate = false, false
+		case policyMapPolicerRegex.MatchString(line) && class.Policer != nil:
+			m := policyMapPolicerRegex.FindStringSubmatch(line)
+			counter := PolicerCounter{Action: m[4]}
+			counter.Bytes, _ = strconv.ParseUint(m[2], 10, 64)
+			counter.Rate, _ = strconv.ParseUint(m[3], 10, 64)
+			switch m[1] {
+			case "conformed":
+				class.Policer.Conformed = counter
+			case "exceeded":
+				class.Policer.Exceeded = counter
+			case "violated":
+				class.Policer.Violated = counter
+			}
+		case strings.HasPrefix(line, "SNMP Policy Index"):
+		default:
+			class.Actions = append(class.Actions, line)
+			inMatch, inAggregate = false, false
+		}
+	}
+	if pmi.Interface == "" {
+		return nil, fmt.Errorf("no interface found: %s", s)
+	}
+	return pmi, nil
+}
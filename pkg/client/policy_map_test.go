@@ -0,0 +1,112 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestParseShowPolicyMapInterfaceOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *PolicyMapClass
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.policy-map.interface.1",
+			exp: &PolicyMapClass{
+				Name:      "VOICE",
+				MatchType: "match-any",
+				Packets:   120345,
+				Matches: []PolicyMapMatch{
+					{Criteria: "dscp 46", Packets: 120000},
+					{Criteria: "access-group VOICE-ACL", Packets: 345},
+				},
+				Actions: []string{"set qos-group 5"},
+				Policer: &Policer{
+					Config:    "cir 10 mbps bc 200 ms",
+					Conformed: PolicerCounter{Bytes: 98765432, Rate: 1200, Action: "transmit"},
+					Exceeded:  PolicerCounter{Bytes: 1234, Action: "drop"},
+					Violated:  PolicerCounter{Action: "drop"},
+				},
+			},
+			count:      2,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "show.running.config.1",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		pmi, err := NewPolicyMapInterfaceFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, pmi)
+				testFailed++
+				continue
+			}
+		}
+
+		if pmi != nil {
+			if pmi.Interface != "Ethernet1/1" || len(pmi.Policies) != 1 {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to unexpected policies: %v", i, test.input, pmi)
+				testFailed++
+				continue
+			}
+			classes := pmi.Policies[0].Classes
+			if (len(classes) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(classes) [%d] != %d", i, test.input, len(classes), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, &classes[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %+v", i, test.input, classes[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
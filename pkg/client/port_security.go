@@ -0,0 +1,88 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PortSecurity contains port security status of an interface. The
+// information in the structure is from the output of "show port-security"
+// command. The ViolationMode is the action on violation, i.e. "Shutdown",
+// "Restrict" or "Protect".
+type PortSecurity struct {
+	Interface        string `json:"interface" xml:"interface"`
+	MaxAddresses     int    `json:"max_addresses" xml:"max_addresses"`
+	CurrentAddresses int    `json:"current_addresses" xml:"current_addresses"`
+	ViolationCount   uint64 `json:"violation_count" xml:"violation_count"`
+	ViolationMode    string `json:"violation_mode" xml:"violation_mode"`
+}
+
+// NewPortSecurityFromString returns PortSecurity instances from an input
+// string.
+func NewPortSecurityFromString(s string) ([]*PortSecurity, error) {
+	return NewPortSecurityFromBytes([]byte(s))
+}
+
+// NewPortSecurityFromBytes returns PortSecurity instances from an input
+// byte array.
+func NewPortSecurityFromBytes(s []byte) ([]*PortSecurity, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parsePortSecurity(resp.Result.Outputs.Output.Body), nil
+}
+
+// parsePortSecurity parses the text output of "show port-security", e.g.
+//
+//	----------------------------------------------------------------------------
+//	Secure Port  MaxSecureAddr  CurrentAddr  SecurityViolation  Security Action
+//	                (Count)       (Count)          (Count)
+//	----------------------------------------------------------------------------
+//	Ethernet1/5          1              1                 0              Shutdown
+func parsePortSecurity(s string) []*PortSecurity {
+	entries := []*PortSecurity{}
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 5 {
+			continue
+		}
+		entry := &PortSecurity{
+			Interface:     fields[0],
+			ViolationMode: fields[4],
+		}
+		var err error
+		if entry.MaxAddresses, err = strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		if entry.CurrentAddresses, err = strconv.Atoi(fields[2]); err != nil {
+			continue
+		}
+		if entry.ViolationCount, err = strconv.ParseUint(fields[3], 10, 64); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	queuingInterfaceRegex = regexp.MustCompile(`^Egress Queuing for (\S+)`)
+	queuingSchedulerRegex = regexp.MustCompile(`^(\d+)\s+(\d+|-)\s+(\d+|-)\s`)
+	queuingGroupRegex     = regexp.MustCompile(`^\|\s*QOS GROUP (\d+)\s*\|$`)
+)
+
+// QueueCounters are the counters of a Queue for a particular traffic type,
+// i.e. unicast or multicast. The DroppedPackets and DroppedBytes are the
+// WRED/AFD and tail drops, and the WatchdogDroppedPackets are the drops
+// of the queue watchdog (WD) and tail drops.
+type QueueCounters struct {
+	TxPackets              uint64 `json:"tx_packets" xml:"tx_packets"`
+	TxBytes                uint64 `json:"tx_bytes" xml:"tx_bytes"`
+	DroppedPackets         uint64 `json:"dropped_packets" xml:"dropped_packets"`
+	DroppedBytes           uint64 `json:"dropped_bytes" xml:"dropped_bytes"`
+	WatchdogDroppedPackets uint64 `json:"watchdog_dropped_packets" xml:"watchdog_dropped_packets"`
+	QueueDepthBytes        uint64 `json:"queue_depth_bytes" xml:"queue_depth_bytes"`
+}
+
+// Queue is an egress queue of an interface. A Bandwidth or PriorityLevel
+// of -1 indicates that the value is not set.
+type Queue struct {
+	QoSGroup      int           `json:"qos_group" xml:"qos_group"`
+	Bandwidth     int           `json:"bandwidth" xml:"bandwidth"`
+	PriorityLevel int           `json:"priority_level" xml:"priority_level"`
+	Unicast       QueueCounters `json:"unicast" xml:"unicast"`
+	Multicast     QueueCounters `json:"multicast" xml:"multicast"`
+}
+
+// Queuing contains queuing statistics of an interface. The information in
+// the structure is from the output of "show queuing interface" command.
+type Queuing struct {
+	Interface string   `json:"interface" xml:"interface"`
+	Queues    []*Queue `json:"queues" xml:"queues"`
+}
+
+// NewQueuingFromString returns Queuing instance from an input string.
+func NewQueuingFromString(s string) (*Queuing, error) {
+	return NewQueuingFromBytes([]byte(s))
+}
+
+// NewQueuingFromBytes returns Queuing instance from an input byte array.
+func NewQueuingFromBytes(s []byte) (*Queuing, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseQueuing(resp.Result.Outputs.Output.Body)
+}
+
+func parseQueuing(s string) (*Queuing, error) {
+	q := &Queuing{
+		Queues: []*Queue{},
+	}
+	groups := make(map[int]*Queue)
+	getQueue := func(id int) *Queue {
+		if queue, exists := groups[id]; exists {
+			return queue
+		}
+		queue := &Queue{QoSGroup: id, Bandwidth: -1, PriorityLevel: -1}
+		groups[id] = queue
+		q.Queues = append(q.Queues, queue)
+		return queue
+	}
+	var queue *Queue
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if m := queuingInterfaceRegex.FindStringSubmatch(line); m != nil {
+			q.Interface = m[1]
+			continue
+		}
+		if m := queuingSchedulerRegex.FindStringSubmatch(line); m != nil && queue == nil {
+			id, _ := strconv.Atoi(m[1])
+			entry := getQueue(id)
+			if m[2] != "-" {
+				entry.Bandwidth, _ = strconv.Atoi(m[2])
+			}
+			if m[3] != "-" {
+				entry.PriorityLevel, _ = strconv.Atoi(m[3])
+			}
+			continue
+		}
+		if m := queuingGroupRegex.FindStringSubmatch(line); m != nil {
+			id, _ := strconv.Atoi(m[1])
+			queue = getQueue(id)
+			continue
+		}
+		if queue == nil || !strings.HasPrefix(line, "|") {
+			continue
+		}
+		cells := strings.Split(strings.Trim(line, "|"), "|")
+		if len(cells) != 3 {
+			continue
+		}
+		label := strings.TrimSpace(cells[0])
+		unicast, err := strconv.ParseUint(strings.TrimSpace(cells[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+		multicast, _ := strconv.ParseUint(strings.TrimSpace(cells[2]), 10, 64)
+		var ucast, mcast *uint64
+		switch {
+		case label == "Tx Pkts":
+			ucast, mcast = &queue.Unicast.TxPackets, &queue.Multicast.TxPackets
+		case label == "Tx Byts":
+			ucast, mcast = &queue.Unicast.TxBytes, &queue.Multicast.TxBytes
+		case label == "WRED/AFD & Tail Drop Pkts":
+			ucast, mcast = &queue.Unicast.DroppedPackets, &queue.Multicast.DroppedPackets
+		case label == "WRED/AFD & Tail Drop Byts":
+			ucast, mcast = &queue.Unicast.DroppedBytes, &queue.Multicast.DroppedBytes
+		case label == "WD & Tail Drop Pkts":
+			ucast, mcast = &queue.Unicast.WatchdogDroppedPackets, &queue.Multicast.WatchdogDroppedPackets
+		case label == "Q Depth Byts":
+			ucast, mcast = &queue.Unicast.QueueDepthBytes, &queue.Multicast.QueueDepthBytes
+		default:
+			continue
+		}
+		*ucast = unicast
+		*mcast = multicast
+	}
+	if q.Interface == "" {
+		return nil, fmt.Errorf("no egress queuing found: %s", s)
+	}
+	return q, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestParseShowQueuingInterfaceOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        []*Queue
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.queuing.interface.1",
+			exp: []*Queue{
+				{
+					QoSGroup:      3,
+					Bandwidth:     -1,
+					PriorityLevel: 1,
+					Unicast:       QueueCounters{TxPackets: 1928374, TxBytes: 246831872},
+				},
+				{QoSGroup: 2, Bandwidth: 0, PriorityLevel: -1},
+				{QoSGroup: 1, Bandwidth: 0, PriorityLevel: -1},
+				{
+					QoSGroup:      0,
+					Bandwidth:     100,
+					PriorityLevel: -1,
+					Unicast: QueueCounters{
+						TxPackets:              584127349,
+						TxBytes:                719263781244,
+						DroppedPackets:         18273,
+						DroppedBytes:           23918223,
+						WatchdogDroppedPackets: 412,
+						QueueDepthBytes:        52416,
+					},
+					Multicast: QueueCounters{TxPackets: 912734, TxBytes: 102983471},
+				},
+			},
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "show.running.config.1",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		queuing, err := NewQueuingFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, queuing)
+				testFailed++
+				continue
+			}
+		}
+
+		if queuing != nil {
+			if !reflect.DeepEqual(test.exp, queuing.Queues) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch", i, test.input)
+				for _, q := range queuing.Queues {
+					t.Logf("%+v", q)
+				}
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+)
+
+// RedactedSecret replaces secrets, e.g. SNMP communities, in the
+// structures returned by the client.
+const RedactedSecret = "<redacted>"
+
+type snmpHostResponse struct {
+	ID      uint64                 `json:"id" xml:"id"`
+	Version string                 `json:"jsonrpc" xml:"jsonrpc"`
+	Result  snmpHostResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError  `json:"error,omitempty" xml:"error"`
+}
+
+type snmpHostResponseResult struct {
+	Body snmpHostResponseResultBody `json:"body" xml:"body"`
+}
+
+type snmpHostResponseResultBody struct {
+	HostTable []struct {
+		HostRow []struct {
+			Host         string `json:"host" xml:"host"`
+			Port         int    `json:"port" xml:"port"`
+			Version      string `json:"version" xml:"version"`
+			Level        string `json:"level" xml:"level"`
+			Type         string `json:"type" xml:"type"`
+			SecurityName string `json:"secname" xml:"secname"`
+			Vrf          string `json:"use_vrf_name" xml:"use_vrf_name"`
+			SourceIntf   string `json:"src_intf_name" xml:"src_intf_name"`
+		} `json:"ROW_host" xml:"ROW_host"`
+	} `json:"TABLE_host" xml:"TABLE_host"`
+}
+
+type snmpCommunityResponse struct {
+	ID      uint64                      `json:"id" xml:"id"`
+	Version string                      `json:"jsonrpc" xml:"jsonrpc"`
+	Result  snmpCommunityResponseResult `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError       `json:"error,omitempty" xml:"error"`
+}
+
+type snmpCommunityResponseResult struct {
+	Body snmpCommunityResponseResultBody `json:"body" xml:"body"`
+}
+
+type snmpCommunityResponseResultBody struct {
+	CommunityTable []struct {
+		CommunityRow []struct {
+			Name   string `json:"community_name" xml:"community_name"`
+			Group  string `json:"grouporaccess" xml:"grouporaccess"`
+			Filter string `json:"aclfilter" xml:"aclfilter"`
+		} `json:"ROW_snmp_community" xml:"ROW_snmp_community"`
+	} `json:"TABLE_snmp_community" xml:"TABLE_snmp_community"`
+}
+
+// SNMPHost is an SNMP notification receiver. The information in the
+// structure is from the output of "show snmp host" command. For SNMPv1 and
+// SNMPv2c hosts the security name is the community, and it is redacted.
+type SNMPHost struct {
+	Host            string `json:"host" xml:"host"`
+	Port            int    `json:"port" xml:"port"`
+	Version         string `json:"version" xml:"version"`
+	Level           string `json:"level" xml:"level"`
+	Type            string `json:"type" xml:"type"`
+	SecurityName    string `json:"security_name" xml:"security_name"`
+	Vrf             string `json:"vrf" xml:"vrf"`
+	SourceInterface string `json:"source_interface" xml:"source_interface"`
+	community       string
+}
+
+// HasCommunity returns true when the host receives notifications with
+// the community.
+func (h *SNMPHost) HasCommunity(community string) bool {
+	return h.community != "" && h.community == community
+}
+
+// SNMPCommunity is an SNMP community. The information in the structure is
+// from the output of "show snmp community" command. The name of the
+// community is redacted.
+type SNMPCommunity struct {
+	Name      string `json:"name" xml:"name"`
+	Group     string `json:"group" xml:"group"`
+	ACLFilter string `json:"acl_filter" xml:"acl_filter"`
+	community string
+}
+
+// Is returns true when the community matches the one provided.
+func (c *SNMPCommunity) Is(community string) bool {
+	return c.community == community
+}
+
+// NewSNMPHostsFromString returns SNMPHost instances from an input string.
+func NewSNMPHostsFromString(s string) ([]*SNMPHost, error) {
+	return NewSNMPHostsFromBytes([]byte(s))
+}
+
+// NewSNMPHostsFromBytes returns SNMPHost instances from an input byte array.
+func NewSNMPHostsFromBytes(s []byte) ([]*SNMPHost, error) {
+	var hosts []*SNMPHost
+	resp := &snmpHostResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		// the server response is not part of the error, because it holds
+		// the communities.
+		return nil, fmt.Errorf("parsing error: %s", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.HostTable {
+		for _, r := range t.HostRow {
+			h := &SNMPHost{
+				Host:            r.Host,
+				Port:            r.Port,
+				Version:         r.Version,
+				Level:           r.Level,
+				Type:            r.Type,
+				SecurityName:    r.SecurityName,
+				Vrf:             r.Vrf,
+				SourceInterface: r.SourceIntf,
+			}
+			if r.Version != "v3" {
+				h.community = r.SecurityName
+				h.SecurityName = RedactedSecret
+			}
+			hosts = append(hosts, h)
+		}
+	}
+	return hosts, nil
+}
+
+// NewSNMPCommunitiesFromString returns SNMPCommunity instances from an
+// input string.
+func NewSNMPCommunitiesFromString(s string) ([]*SNMPCommunity, error) {
+	return NewSNMPCommunitiesFromBytes([]byte(s))
+}
+
+// NewSNMPCommunitiesFromBytes returns SNMPCommunity instances from an
+// input byte array.
+func NewSNMPCommunitiesFromBytes(s []byte) ([]*SNMPCommunity, error) {
+	var communities []*SNMPCommunity
+	resp := &snmpCommunityResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		// the server response is not part of the error, because it holds
+		// the communities.
+		return nil, fmt.Errorf("parsing error: %s", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.CommunityTable {
+		for _, r := range t.CommunityRow {
+			communities = append(communities, &SNMPCommunity{
+				Name:      RedactedSecret,
+				Group:     r.Group,
+				ACLFilter: r.Filter,
+				community: r.Name,
+			})
+		}
+	}
+	return communities, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseShowSNMPHostJsonOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *SNMPHost
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.snmp.host.1",
+			exp: &SNMPHost{
+				Host:         "10.0.0.20",
+				Port:         162,
+				Version:      "v2c",
+				Level:        "noauth",
+				Type:         "trap",
+				SecurityName: RedactedSecret,
+				Vrf:          "management",
+				community:    "s3cr3t-Tr4p",
+			},
+			count:      2,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		items, err := NewSNMPHostsFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, items)
+				testFailed++
+				continue
+			}
+		}
+
+		if items != nil {
+			if (len(items) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(items) [%d] != %d", i, test.input, len(items), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, items[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, items[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}
+
+func TestSNMPCommunityRedaction(t *testing.T) {
+	fp := "../../assets/requests/resp.show.snmp.community.1.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	communities, err := NewSNMPCommunitiesFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+	if len(communities) != 1 {
+		t.Fatalf("unexpected number of communities: %d", len(communities))
+	}
+	c := communities[0]
+	if c.Name != RedactedSecret || c.Group != "network-operator" || c.ACLFilter != "SNMP-ACL" {
+		t.Fatalf("unexpected community: %v", c)
+	}
+	if !c.Is("r3ad-0nly") || c.Is("public") {
+		t.Fatalf("community comparison failed")
+	}
+	output, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("failed marshaling community: %s", err)
+	}
+	if strings.Contains(string(output), "r3ad-0nly") {
+		t.Fatalf("community leaked: %s", output)
+	}
+	// the malformed response, e.g. truncated, is not part of the error.
+	truncated := content[:bytes.Index(content, []byte("r3ad-0nly"))+len("r3ad-0nly")+2]
+	if _, err := NewSNMPCommunitiesFromBytes(truncated); err == nil {
+		t.Fatalf("expected error for truncated response")
+	} else if strings.Contains(err.Error(), "r3ad-0nly") {
+		t.Fatalf("community leaked: %s", err)
+	}
+	if _, err := NewSNMPHostsFromBytes(truncated); err == nil {
+		t.Fatalf("expected error for truncated response")
+	} else if strings.Contains(err.Error(), "r3ad-0nly") {
+		t.Fatalf("community leaked: %s", err)
+	}
+}
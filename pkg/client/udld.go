@@ -0,0 +1,92 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// UDLDNeighbor is a UDLD neighbor. The information in the structure is
+// from the output of "show udld neighbors" command.
+type UDLDNeighbor struct {
+	Interface  string `json:"interface" xml:"interface"`
+	DeviceName string `json:"device_name" xml:"device_name"`
+	DeviceID   string `json:"device_id" xml:"device_id"`
+	PortID     string `json:"port_id" xml:"port_id"`
+	State      string `json:"state" xml:"state"`
+}
+
+// IsBidirectional returns true when the link to the neighbor is
+// bidirectional.
+func (n *UDLDNeighbor) IsBidirectional() bool {
+	return n.State == "bidirectional"
+}
+
+// NewUDLDNeighborsFromString returns UDLDNeighbor instances from an input
+// string.
+func NewUDLDNeighborsFromString(s string) ([]*UDLDNeighbor, error) {
+	return NewUDLDNeighborsFromBytes([]byte(s))
+}
+
+// NewUDLDNeighborsFromBytes returns UDLDNeighbor instances from an input
+// byte array.
+func NewUDLDNeighborsFromBytes(s []byte) ([]*UDLDNeighbor, error) {
+	resp := &insAPIResponse{}
+	err := json.Unmarshal(s, resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Result.Outputs.Output.Code != "200" {
+		return nil, fmt.Errorf("error: %s, %s, server response: %s",
+			resp.Result.Outputs.Output.Code, resp.Result.Outputs.Output.Message, string(s[:]))
+	}
+	return parseUDLDNeighbors(resp.Result.Outputs.Output.Body)
+}
+
+// parseUDLDNeighbors parses the text output of "show udld neighbors", e.g.
+//
+//	Port                Device Name     Device ID     Port ID         Neighbor State
+//	---------------------------------------------------------------------------------
+//	Ethernet1/1         FOC1234ABCD       1           Ethernet1/1     bidirectional
+func parseUDLDNeighbors(s string) ([]*UDLDNeighbor, error) {
+	neighbors := []*UDLDNeighbor{}
+	header := false
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "---") {
+			continue
+		}
+		if fields[0] == "Port" {
+			header = true
+			continue
+		}
+		if !header {
+			continue
+		}
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("malformed udld neighbor entry: %s", line)
+		}
+		neighbors = append(neighbors, &UDLDNeighbor{
+			Interface:  fields[0],
+			DeviceName: fields[1],
+			DeviceID:   fields[2],
+			PortID:     fields[3],
+			State:      fields[4],
+		})
+	}
+	return neighbors, nil
+}
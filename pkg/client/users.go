@@ -0,0 +1,103 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+)
+
+type usersResponse struct {
+	ID      uint64                `json:"id" xml:"id"`
+	Version string                `json:"jsonrpc" xml:"jsonrpc"`
+	Result  usersResponseResult   `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError `json:"error,omitempty" xml:"error"`
+}
+
+type usersResponseResult struct {
+	Body usersResponseResultBody `json:"body" xml:"body"`
+}
+
+type usersResponseResultBody struct {
+	SessionTable []struct {
+		SessionRow []struct {
+			Name      string `json:"u_name" xml:"u_name"`
+			Line      string `json:"tty" xml:"tty"`
+			LoginTime string `json:"login_time" xml:"login_time"`
+			Idle      string `json:"idle" xml:"idle"`
+			PID       int    `json:"pid" xml:"pid"`
+			Comment   string `json:"comment" xml:"comment"`
+			Current   string `json:"cur" xml:"cur"`
+		} `json:"ROW_sessions" xml:"ROW_sessions"`
+	} `json:"TABLE_sessions" xml:"TABLE_sessions"`
+}
+
+// UserSession contains information about a user session. The information
+// in the structure is from the output of "show users" command.
+type UserSession struct {
+	Name      string `json:"name" xml:"name"`
+	Line      string `json:"line" xml:"line"`
+	LoginTime string `json:"login_time" xml:"login_time"`
+	Idle      string `json:"idle" xml:"idle"`
+	PID       int    `json:"pid" xml:"pid"`
+	From      string `json:"from" xml:"from"`
+	Comment   string `json:"comment" xml:"comment"`
+	Current   bool   `json:"current" xml:"current"`
+}
+
+// NewUserSessionsFromString returns UserSession instances from an input string.
+func NewUserSessionsFromString(s string) ([]*UserSession, error) {
+	return NewUserSessionsFromBytes([]byte(s))
+}
+
+// NewUserSessionsFromBytes returns UserSession instances from an input byte array.
+func NewUserSessionsFromBytes(s []byte) ([]*UserSession, error) {
+	var sessions []*UserSession
+	resp := &usersResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.SessionTable {
+		for _, r := range t.SessionRow {
+			session := &UserSession{
+				Name:      r.Name,
+				Line:      r.Line,
+				LoginTime: r.LoginTime,
+				Idle:      r.Idle,
+				PID:       r.PID,
+				Comment:   r.Comment,
+				Current:   r.Current == "*",
+			}
+			// the comment starts with the remote address in parentheses,
+			// e.g. "(10.0.0.5) session=ssh".
+			if strings.HasPrefix(r.Comment, "(") {
+				if i := strings.Index(r.Comment, ")"); i > 0 {
+					session.From = r.Comment[1:i]
+				}
+			}
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
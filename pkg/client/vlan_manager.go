@@ -0,0 +1,207 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The actions of VlanChange.
+const (
+	VlanCreate = "create"
+	VlanUpdate = "update"
+	VlanDelete = "delete"
+)
+
+// VlanSpec is the desired configuration of a VLAN. An empty Name leaves
+// the name of the VLAN as is, and an empty State and Mode default to
+// "active" and "ce".
+type VlanSpec struct {
+	ID    int    `json:"id" xml:"id"`
+	Name  string `json:"name" xml:"name"`
+	State string `json:"state" xml:"state"`
+	Mode  string `json:"mode" xml:"mode"`
+}
+
+// VlanChange is a change of a VLAN, with the commands making it.
+type VlanChange struct {
+	ID       int      `json:"id" xml:"id"`
+	Action   string   `json:"action" xml:"action"`
+	Changes  []string `json:"changes" xml:"changes"`
+	Commands []string `json:"commands" xml:"commands"`
+}
+
+// VlanPlan contains the changes reconciling the VLANs of a device with
+// their desired configuration.
+type VlanPlan struct {
+	Changes []*VlanChange `json:"changes" xml:"changes"`
+	Applied bool          `json:"applied" xml:"applied"`
+}
+
+// VlanReconcileOptions are the options of ReconcileVlans. With Prune, the
+// VLANs without VlanSpec, except the default VLAN, are deleted. With
+// DryRun, the changes are planned, but not applied.
+type VlanReconcileOptions struct {
+	Prune  bool
+	DryRun bool
+}
+
+// IsEmpty returns true when the VLANs are in the desired state.
+func (p *VlanPlan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// Commands returns the commands of all the changes.
+func (p *VlanPlan) Commands() []string {
+	var cmds []string
+	for _, c := range p.Changes {
+		cmds = append(cmds, c.Commands...)
+	}
+	return cmds
+}
+
+// PlanVlans returns the changes transforming the current VLANs, e.g. from
+// GetVlans, into the desired ones.
+func PlanVlans(current []*Vlan, desired []*VlanSpec, prune bool) (*VlanPlan, error) {
+	plan := &VlanPlan{
+		Changes: []*VlanChange{},
+	}
+	existing := make(map[int]*Vlan)
+	for _, vlan := range current {
+		id, err := strconv.Atoi(vlan.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vlan id: %s", vlan.ID)
+		}
+		existing[id] = vlan
+	}
+	wanted := make(map[int]bool)
+	for _, spec := range desired {
+		if err := spec.validate(); err != nil {
+			return nil, err
+		}
+		if wanted[spec.ID] {
+			return nil, fmt.Errorf("duplicate vlan: %d", spec.ID)
+		}
+		wanted[spec.ID] = true
+		if change := spec.plan(existing[spec.ID]); change != nil {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+	if prune {
+		for id := range existing {
+			if wanted[id] || id == 1 {
+				continue
+			}
+			plan.Changes = append(plan.Changes, &VlanChange{
+				ID:       id,
+				Action:   VlanDelete,
+				Changes:  []string{},
+				Commands: []string{fmt.Sprintf("no vlan %d", id)},
+			})
+		}
+	}
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		return plan.Changes[i].ID < plan.Changes[j].ID
+	})
+	return plan, nil
+}
+
+func (spec *VlanSpec) validate() error {
+	if spec.ID < 1 || spec.ID > 4094 {
+		return fmt.Errorf("invalid vlan id: %d", spec.ID)
+	}
+	if spec.ID == 1 {
+		if (spec.Name != "" && spec.Name != "default") || spec.state() != "active" || spec.mode() != "ce" {
+			return fmt.Errorf("vlan 1 cannot be modified")
+		}
+	}
+	if len(spec.Name) > 32 || strings.Contains(spec.Name, " ") || hasControlChars(spec.Name) {
+		return fmt.Errorf("invalid vlan %d name: %q", spec.ID, spec.Name)
+	}
+	switch spec.state() {
+	case "active", "suspend":
+	default:
+		return fmt.Errorf("invalid vlan %d state: %s", spec.ID, spec.State)
+	}
+	switch spec.mode() {
+	case "ce", "fabricpath":
+	default:
+		return fmt.Errorf("invalid vlan %d mode: %s", spec.ID, spec.Mode)
+	}
+	return nil
+}
+
+func (spec *VlanSpec) state() string {
+	if spec.State == "" {
+		return "active"
+	}
+	return spec.State
+}
+
+func (spec *VlanSpec) mode() string {
+	if spec.Mode == "" {
+		return "ce"
+	}
+	return spec.Mode
+}
+
+// plan returns the change of a VLAN, or nil when the VLAN is in the
+// desired state.
+func (spec *VlanSpec) plan(vlan *Vlan) *VlanChange {
+	change := &VlanChange{
+		ID:      spec.ID,
+		Action:  VlanUpdate,
+		Changes: []string{},
+	}
+	var name, state, mode string
+	if vlan == nil {
+		change.Action = VlanCreate
+		state, mode = "active", "ce"
+	} else {
+		name = vlan.Name
+		// the state of a vlan shut down locally is e.g. "act/lshut".
+		switch strings.SplitN(vlan.State, "/", 2)[0] {
+		case "sus", "suspend", "suspended":
+			state = "suspend"
+		default:
+			state = "active"
+		}
+		mode = strings.TrimSuffix(vlan.Mode, "-vlan")
+		if mode == "" {
+			mode = "ce"
+		}
+	}
+	var cmds []string
+	if spec.Name != "" && spec.Name != name {
+		cmds = append(cmds, "name "+spec.Name)
+		change.Changes = append(change.Changes, fmt.Sprintf("name: %q -> %q", name, spec.Name))
+	}
+	if spec.state() != state {
+		cmds = append(cmds, "state "+spec.state())
+		change.Changes = append(change.Changes, fmt.Sprintf("state: %s -> %s", state, spec.state()))
+	}
+	if spec.mode() != mode {
+		cmds = append(cmds, "mode "+spec.mode())
+		change.Changes = append(change.Changes, fmt.Sprintf("mode: %s -> %s", mode, spec.mode()))
+	}
+	if vlan != nil && len(cmds) == 0 {
+		return nil
+	}
+	change.Commands = append([]string{fmt.Sprintf("vlan %d", spec.ID)}, cmds...)
+	return change
+}
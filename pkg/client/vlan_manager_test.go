@@ -0,0 +1,86 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestPlanVlans(t *testing.T) {
+	fp := "../../assets/requests/resp.show.vlans.2.json"
+	content, err := ioutil.ReadFile(fp)
+	if err != nil {
+		t.Fatalf("failed reading '%s', error: %v", fp, err)
+	}
+	current, err := NewVlansFromBytes(content)
+	if err != nil {
+		t.Fatalf("failed parsing '%s', error: %v", fp, err)
+	}
+
+	desired := []*VlanSpec{
+		{ID: 20, Name: "STORAGE", State: "suspend"},
+		{ID: 2345, Name: "VLAN2345"},
+		{ID: 10, Name: "SERVERS"},
+	}
+	plan, err := PlanVlans(current, desired, true)
+	if err != nil {
+		t.Fatalf("failed planning vlans: %v", err)
+	}
+	exp := []*VlanChange{
+		{ID: 10, Action: VlanCreate, Changes: []string{`name: "" -> "SERVERS"`}, Commands: []string{"vlan 10", "name SERVERS"}},
+		{ID: 20, Action: VlanCreate, Changes: []string{`name: "" -> "STORAGE"`, "state: active -> suspend"}, Commands: []string{"vlan 20", "name STORAGE", "state suspend"}},
+	}
+	if !reflect.DeepEqual(exp, plan.Changes) {
+		t.Fatalf("unexpected changes: %v", plan.Commands())
+	}
+
+	plan, err = PlanVlans(current, []*VlanSpec{{ID: 1}, {ID: 30, Mode: "fabricpath"}}, true)
+	if err != nil {
+		t.Fatalf("failed planning vlans: %v", err)
+	}
+	expCommands := []string{"vlan 30", "mode fabricpath", "no vlan 2345"}
+	if !reflect.DeepEqual(expCommands, plan.Commands()) {
+		t.Fatalf("unexpected commands: %v", plan.Commands())
+	}
+	if plan.Changes[1].Action != VlanDelete {
+		t.Fatalf("unexpected change: %v", plan.Changes[1])
+	}
+
+	plan, err = PlanVlans(current, []*VlanSpec{{ID: 2345}}, false)
+	if err != nil {
+		t.Fatalf("failed planning vlans: %v", err)
+	}
+	if !plan.IsEmpty() {
+		t.Fatalf("unexpected changes: %v", plan.Commands())
+	}
+
+	for i, desired := range [][]*VlanSpec{
+		{{ID: 4095}},
+		{{ID: 10}, {ID: 10}},
+		{{ID: 1, Name: "users"}},
+		{{ID: 10, Name: "two words"}},
+		{{ID: 10, Name: "a\nno"}},
+		{{ID: 10, Name: "a\rno"}},
+		{{ID: 10, Name: "a\x1bno"}},
+		{{ID: 10, State: "shutdown"}},
+		{{ID: 10, Mode: "private"}},
+	} {
+		if _, err := PlanVlans(current, desired, false); err == nil {
+			t.Fatalf("test %d: expected error for invalid vlans", i)
+		}
+	}
+}
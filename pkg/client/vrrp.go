@@ -0,0 +1,119 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pschou/go-json"
+	"strings"
+	"time"
+)
+
+type vrrpResponse struct {
+	ID      uint64                `json:"id" xml:"id"`
+	Version string                `json:"jsonrpc" xml:"jsonrpc"`
+	Result  vrrpResponseResult    `json:"result" xml:"result"`
+	Error   *JSONRPCResponseError `json:"error,omitempty" xml:"error"`
+}
+
+type vrrpResponseResult struct {
+	Body vrrpResponseResultBody `json:"body" xml:"body"`
+}
+
+type vrrpResponseResultBody struct {
+	GroupTable []struct {
+		GroupRow []vrrpResponseResultBodyGroupRow `json:"ROW_vrrp_group" xml:"ROW_vrrp_group"`
+	} `json:"TABLE_vrrp_group" xml:"TABLE_vrrp_group"`
+}
+
+type vrrpResponseResultBodyGroupRow struct {
+	Interface             string `json:"sh_if_index" xml:"sh_if_index"`
+	Group                 int    `json:"sh_group_id" xml:"sh_group_id"`
+	AddressFamily         string `json:"sh_group_type" xml:"sh_group_type"`
+	State                 string `json:"sh_group_state" xml:"sh_group_state"`
+	Priority              int    `json:"sh_priority" xml:"sh_priority"`
+	Preempt               string `json:"sh_preempt" xml:"sh_preempt"`
+	AdvertisementInterval int    `json:"sh_adv_interval" xml:"sh_adv_interval"`
+	AdvertisementUnit     string `json:"sh_adv_interval_attr" xml:"sh_adv_interval_attr"`
+	VirtualIP             string `json:"sh_vip_addr" xml:"sh_vip_addr"`
+	VirtualMAC            string `json:"sh_vmac" xml:"sh_vmac"`
+	MasterRouter          string `json:"sh_master_addr" xml:"sh_master_addr"`
+	MasterRouterPriority  int    `json:"sh_master_priority" xml:"sh_master_priority"`
+}
+
+// VRRPGroup contains VRRP group information. The information in the
+// structure is from the output of "show vrrp detail" command.
+type VRRPGroup struct {
+	Interface             string        `json:"interface" xml:"interface"`
+	Group                 int           `json:"group" xml:"group"`
+	AddressFamily         string        `json:"address_family" xml:"address_family"`
+	State                 string        `json:"state" xml:"state"`
+	Priority              int           `json:"priority" xml:"priority"`
+	Preempt               bool          `json:"preempt" xml:"preempt"`
+	AdvertisementInterval time.Duration `json:"advertisement_interval" xml:"advertisement_interval"`
+	VirtualIP             string        `json:"virtual_ip" xml:"virtual_ip"`
+	VirtualMAC            string        `json:"virtual_mac" xml:"virtual_mac"`
+	MasterRouter          string        `json:"master_router" xml:"master_router"`
+	MasterRouterPriority  int           `json:"master_router_priority" xml:"master_router_priority"`
+}
+
+// IsMaster returns true when the local router is the master router
+// for the group.
+func (g *VRRPGroup) IsMaster() bool {
+	return strings.EqualFold(g.State, "master")
+}
+
+// NewVRRPGroupsFromString returns VRRPGroup instances from an input string.
+func NewVRRPGroupsFromString(s string) ([]*VRRPGroup, error) {
+	return NewVRRPGroupsFromBytes([]byte(s))
+}
+
+// NewVRRPGroupsFromBytes returns VRRPGroup instances from an input byte array.
+func NewVRRPGroupsFromBytes(s []byte) ([]*VRRPGroup, error) {
+	var groups []*VRRPGroup
+	resp := &vrrpResponse{}
+	jsonDec := json.NewDecoder(bytes.NewReader(s))
+	jsonDec.UseAutoConvert()
+	jsonDec.UseSlice()
+	err := jsonDec.Decode(resp)
+	if err != nil {
+		return nil, fmt.Errorf("parsing error: %s, server response: %s", err, string(s[:]))
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("command returned failure: %v", resp.Error)
+	}
+	for _, t := range resp.Result.Body.GroupTable {
+		for _, r := range t.GroupRow {
+			g := &VRRPGroup{
+				Interface:             r.Interface,
+				Group:                 r.Group,
+				AddressFamily:         r.AddressFamily,
+				State:                 r.State,
+				Priority:              r.Priority,
+				AdvertisementInterval: fhrpTimer(r.AdvertisementInterval, r.AdvertisementUnit),
+				VirtualIP:             r.VirtualIP,
+				VirtualMAC:            r.VirtualMAC,
+				MasterRouter:          r.MasterRouter,
+				MasterRouterPriority:  r.MasterRouterPriority,
+			}
+			if strings.HasPrefix(strings.ToLower(r.Preempt), "enable") {
+				g.Preempt = true
+			}
+			groups = append(groups, g)
+		}
+	}
+	return groups, nil
+}
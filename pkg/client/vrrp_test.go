@@ -0,0 +1,104 @@
+// Copyright 2018 Paul Greenberg greenpau@outlook.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseShowVRRPDetailJsonOutput(t *testing.T) {
+	testFailed := 0
+	outputDir := "../../assets/requests"
+	for i, test := range []struct {
+		input      string
+		exp        *VRRPGroup
+		count      int
+		shouldFail bool
+		shouldErr  bool
+	}{
+		{
+			input: "show.vrrp.detail.1",
+			exp: &VRRPGroup{
+				Interface:             "Vlan300",
+				Group:                 30,
+				AddressFamily:         "IPV4",
+				State:                 "Master",
+				Priority:              120,
+				Preempt:               true,
+				AdvertisementInterval: time.Second,
+				VirtualIP:             "10.3.0.1",
+				VirtualMAC:            "0000.5e00.011e",
+				MasterRouter:          "10.3.0.2",
+				MasterRouterPriority:  120,
+			},
+			count:      2,
+			shouldFail: false,
+			shouldErr:  false,
+		},
+		{
+			input:      "show.vrrp.detail.2",
+			shouldFail: false,
+			shouldErr:  true,
+		},
+	} {
+		fp := fmt.Sprintf("%s/resp.%s.json", outputDir, test.input)
+		content, err := ioutil.ReadFile(fp)
+		if err != nil {
+			t.Logf("FAIL: Test %d: failed reading '%s', error: %v", i, fp, err)
+			testFailed++
+			continue
+		}
+		groups, err := NewVRRPGroupsFromBytes(content)
+		if err != nil {
+			if !test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but threw error: %v", i, test.input, err)
+				testFailed++
+				continue
+			}
+		} else {
+			if test.shouldErr {
+				t.Logf("FAIL: Test %d: input '%s', expected to throw error, but passed: %v", i, test.input, groups)
+				testFailed++
+				continue
+			}
+		}
+
+		if groups != nil {
+			if (len(groups) != test.count) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to len(groups) [%d] != %d", i, test.input, len(groups), test.count)
+				testFailed++
+				continue
+			}
+			if !reflect.DeepEqual(test.exp, groups[0]) && !test.shouldFail {
+				t.Logf("FAIL: Test %d: input '%s', expected to pass, but failed due to mismatch: %v", i, test.input, groups[0])
+				testFailed++
+				continue
+			}
+		}
+
+		if test.shouldFail {
+			t.Logf("PASS: Test %d: input '%s', expected to fail, failed", i, test.input)
+		} else {
+			t.Logf("PASS: Test %d: input '%s', expected to pass, passed", i, test.input)
+		}
+	}
+	if testFailed > 0 {
+		t.Fatalf("Failed %d tests", testFailed)
+	}
+}